@@ -66,6 +66,23 @@ data "kion_aws_iam_policy" "p1" {
   }
 }
 
+# Declare a data source to get all IAM policies that are owned only by users
+# with an ID greater than 10 and whose name doesn't start with "System".
+data "kion_aws_iam_policy" "p1" {
+  filter {
+    name        = "owner_users.id"
+    operator    = "gt"
+    array_match = "all"
+    values      = ["10"]
+  }
+  filter {
+    name     = "name"
+    operator = "not_equals"
+    regex    = true
+    values   = ["^System"]
+  }
+}
+
 # Declare a data source to get all IAM policies that matches the id filter.
 # Notice that terraform will convert these to strings even though you
 # passed in an integer.
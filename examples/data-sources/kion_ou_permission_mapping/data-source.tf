@@ -0,0 +1,18 @@
+# Declare a data source to get all permission mappings of an OU.
+data "kion_ou_permission_mapping" "pm1" {
+  ou_id = 12
+}
+
+# Output the users that hold app role 3 on the OU.
+data "kion_ou_permission_mapping" "role3" {
+  ou_id = 12
+  filter {
+    name   = "app_role_id"
+    values = ["3"]
+  }
+  exactly_one = true
+}
+
+output "role3_users" {
+  value = data.kion_ou_permission_mapping.role3.user_ids
+}
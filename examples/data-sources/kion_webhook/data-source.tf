@@ -0,0 +1,11 @@
+# Declare a data source to get all webhooks.
+data "kion_webhook" "all" {}
+
+# Look up a webhook by name and reference it from a cloud rule.
+data "kion_webhook" "notify" {
+  name = "Notify change management"
+}
+
+output "webhook_id" {
+  value = data.kion_webhook.notify.id
+}
@@ -17,3 +17,13 @@ resource "kion_ou" "ou1" {
 output "ou_id" {
   value = kion_ou.ou1.id
 }
+
+# Create an OU whose owners are referenced by username and group name, which
+# stay the same across Kion installations.
+resource "kion_ou" "ou2" {
+  name                   = "sample-ou-2"
+  parent_ou_id           = 0
+  permission_scheme_id   = 2
+  owner_usernames        = ["jdoe"]
+  owner_user_group_names = ["Platform Admins"]
+}
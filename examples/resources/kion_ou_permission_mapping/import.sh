@@ -0,0 +1,2 @@
+# Import the mapping of app role 3 on OU 12 using <ou_id>-<app_role_id>.
+terraform import kion_ou_permission_mapping.pm1 12-3
@@ -0,0 +1,8 @@
+# Grant an app role on an OU to users and user groups. Users and user groups
+# that hold the role on the OU but are not listed here are removed.
+resource "kion_ou_permission_mapping" "pm1" {
+  ou_id       = kion_ou.ou1.id
+  app_role_id = 3
+  users { id = 1 }
+  user_groups { id = 2 }
+}
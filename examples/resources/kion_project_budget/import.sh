@@ -0,0 +1,2 @@
+# Import a budget using its ID.
+terraform import kion_project_budget.b1 7
@@ -0,0 +1,30 @@
+# Create a budget that distributes an amount evenly across the months and
+# funding sources. Funding sources are used in the order they are listed.
+resource "kion_project_budget" "b1" {
+  project_id         = kion_project.p1.id
+  start_datecode     = "2024-01"
+  end_datecode       = "2025-01"
+  amount             = 12000
+  funding_source_ids = [1, 2]
+}
+
+# Create a budget with explicit monthly entries.
+resource "kion_project_budget" "b2" {
+  project_id     = kion_project.p1.id
+  start_datecode = "2025-01"
+  end_datecode   = "2025-03"
+
+  data {
+    datecode          = "2025-01"
+    amount            = 1000
+    funding_source_id = 1
+    priority          = 1
+  }
+
+  data {
+    datecode          = "2025-02"
+    amount            = 1500
+    funding_source_id = 1
+    priority          = 1
+  }
+}
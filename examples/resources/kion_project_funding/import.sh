@@ -0,0 +1,2 @@
+# Import a project funding allocation using its ID.
+terraform import kion_project_funding.fy24 4
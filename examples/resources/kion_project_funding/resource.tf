@@ -0,0 +1,10 @@
+# Allocate funding to a project for a fiscal year. The amount and order can
+# be changed in place.
+resource "kion_project_funding" "fy24" {
+  project_id        = kion_project.p1.id
+  funding_source_id = 1
+  amount            = 10000
+  start_datecode    = "2024-01"
+  end_datecode      = "2024-12"
+  funding_order     = 1
+}
@@ -0,0 +1,2 @@
+# Import the mapping of app role 4 on project 12 using <project_id>-<app_role_id>.
+terraform import kion_project_permission_mapping.pm1 12-4
@@ -0,0 +1,8 @@
+# Grant an app role on a project to users and user groups. Users and user
+# groups that hold the role on the project but are not listed here are removed.
+resource "kion_project_permission_mapping" "pm1" {
+  project_id  = kion_project.p1.id
+  app_role_id = 4
+  users { id = 1 }
+  user_groups { id = 2 }
+}
@@ -0,0 +1,2 @@
+# Import a webhook using its ID.
+terraform import kion_webhook.w1 3
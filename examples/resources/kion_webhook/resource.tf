@@ -0,0 +1,21 @@
+# Create a webhook that is called before a cloud rule is applied.
+resource "kion_webhook" "w1" {
+  name           = "Notify change management"
+  description    = "Opens a change ticket when the cloud rule is applied."
+  callout_url    = "https://hooks.example.com/kion"
+  request_method = "POST"
+  request_headers = {
+    "Content-Type" = "application/json"
+  }
+  request_body       = jsonencode({ text = "A cloud rule is being applied." })
+  timeout_in_seconds = 30
+  owner_users { id = 1 }
+}
+
+# Reference the webhook from a cloud rule.
+resource "kion_cloud_rule" "cr1" {
+  name           = "Baseline"
+  description    = "Baseline cloud rule."
+  pre_webhook_id = kion_webhook.w1.id
+  owner_users { id = 1 }
+}
@@ -0,0 +1,93 @@
+package kion
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile is where the Kion CLI keeps its configuration.
+const defaultConfigFile = "~/.kion.yml"
+
+// kionConfigFile is the subset of the Kion CLI configuration file the
+// provider reads. Settings at the top level form the default profile.
+type kionConfigFile struct {
+	Kion     kionProfile            `yaml:"kion"`
+	Profiles map[string]kionProfile `yaml:"profiles"`
+}
+
+// kionProfile holds the connection settings of a Kion CLI profile.
+type kionProfile struct {
+	URL               string `yaml:"url"`
+	APIKey            string `yaml:"api_key"`
+	Username          string `yaml:"username"`
+	Password          string `yaml:"password"`
+	IDMSID            int    `yaml:"idms_id"`
+	SkipSSLValidation *bool  `yaml:"skip_ssl_validation"`
+}
+
+// UnmarshalYAML reads a profile, whose settings are nested under a kion key
+// like the default profile.
+func (p *kionProfile) UnmarshalYAML(value *yaml.Node) error {
+	type settings kionProfile
+	var nested struct {
+		Kion *settings `yaml:"kion"`
+	}
+	if err := value.Decode(&nested); err != nil {
+		return err
+	}
+	if nested.Kion != nil {
+		*p = kionProfile(*nested.Kion)
+		return nil
+	}
+	return value.Decode((*settings)(p))
+}
+
+// loadKionProfile reads the named profile from the Kion CLI configuration
+// file at path. An empty profile selects the default profile. If required is
+// false a missing file is not an error and yields an empty profile.
+func loadKionProfile(path, profile string, required bool) (kionProfile, error) {
+	expanded, err := expandHome(path)
+	if err != nil {
+		return kionProfile{}, err
+	}
+
+	data, err := os.ReadFile(expanded)
+	if err != nil {
+		if !required && errors.Is(err, os.ErrNotExist) && profile == "" {
+			return kionProfile{}, nil
+		}
+		return kionProfile{}, fmt.Errorf("unable to read the Kion configuration file: %w", err)
+	}
+
+	var config kionConfigFile
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return kionProfile{}, fmt.Errorf("unable to parse the Kion configuration file %s: %w", path, err)
+	}
+
+	if profile == "" {
+		return config.Kion, nil
+	}
+	p, ok := config.Profiles[profile]
+	if !ok {
+		return kionProfile{}, fmt.Errorf("profile %q not found in the Kion configuration file %s", profile, path)
+	}
+	return p, nil
+}
+
+// expandHome replaces a leading ~ in path with the home directory of the
+// current user.
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to expand %s: %w", path, err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
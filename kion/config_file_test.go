@@ -0,0 +1,50 @@
+package kion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadKionProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kion.yml")
+	config := `
+kion:
+  url: https://kion.example.com
+  api_key: app_1_default
+profiles:
+  staging:
+    kion:
+      url: https://kion-staging.example.com
+      api_key: app_1_staging
+      skip_ssl_validation: true
+`
+	assert.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	profile, err := loadKionProfile(path, "", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://kion.example.com", profile.URL)
+	assert.Equal(t, "app_1_default", profile.APIKey)
+	assert.Nil(t, profile.SkipSSLValidation)
+
+	profile, err = loadKionProfile(path, "staging", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://kion-staging.example.com", profile.URL)
+	assert.Equal(t, "app_1_staging", profile.APIKey)
+	assert.True(t, *profile.SkipSSLValidation)
+
+	_, err = loadKionProfile(path, "prod", true)
+	assert.ErrorContains(t, err, `profile "prod" not found`)
+
+	missing := filepath.Join(t.TempDir(), "missing.yml")
+	profile, err = loadKionProfile(missing, "", false)
+	assert.NoError(t, err)
+	assert.Empty(t, profile.URL)
+
+	_, err = loadKionProfile(missing, "", true)
+	assert.Error(t, err)
+	_, err = loadKionProfile(missing, "staging", false)
+	assert.Error(t, err)
+}
@@ -2,9 +2,6 @@ package kion
 
 import (
 	"context"
-	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,34 +9,10 @@ import (
 )
 
 func dataSourceAccount() *schema.Resource {
-	return &schema.Resource{
+	return withSingleLookup("list", &schema.Resource{
 		ReadContext: dataSourceAccountRead,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The field name whose values you wish to filter by.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"values": {
-							Description: "The values of the field name you specified.",
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"regex": {
-							Description: "Dictates if the values provided should be treated as regular expressions.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-					},
-				},
-			},
+			"filter": dataSourceFilterSchema(),
 			"list": {
 				Description: "This is where Kion makes the discovered data available as a list of resources.",
 				Type:        schema.TypeList,
@@ -54,6 +27,12 @@ func dataSourceAccount() *schema.Resource {
 							Type:     schema.TypeInt,
 							Computed: true,
 						},
+						"labels": {
+							Description: "The labels associated with the item. Filter on them with a name such as labels.env.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
 						"name": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -114,7 +93,7 @@ func dataSourceAccount() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -122,13 +101,9 @@ func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, m interf
 	client := m.(*hc.Client)
 
 	resp := new(hc.AccountListResponse)
-	err := client.GET("/v3/account", resp)
+	err := client.GETAllContext(ctx, "/v3/account", resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Account",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Account", err)...)
 		return diags
 	}
 
@@ -154,13 +129,9 @@ func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, m interf
 		data["car_external_id"] = item.CARExternalID
 		data["service_external_id"] = item.ServiceExternalID
 
-		match, err := f.Match(data)
+		match, err := matchWithLabels(ctx, d, client, f, data, "account", item.ID)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to filter Account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "filter"),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter Account", err)...)
 			return diags
 		} else if !match {
 			continue
@@ -170,16 +141,16 @@ func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if err := d.Set("list", arr); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Account",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Account", err)...)
 		return diags
 	}
 
-	// Always run.
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set Account ID", err)...)
+		return diags
+	}
+	d.SetId(id)
 
 	return diags
 }
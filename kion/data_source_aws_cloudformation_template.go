@@ -2,9 +2,6 @@ package kion
 
 import (
 	"context"
-	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,34 +9,10 @@ import (
 )
 
 func dataSourceAwsCloudformationTemplate() *schema.Resource {
-	return &schema.Resource{
+	return withSingleLookup("list", &schema.Resource{
 		ReadContext: dataSourceAwsCloudformationTemplateRead,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The field name whose values you wish to filter by.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"values": {
-							Description: "The values of the field name you specified.",
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"regex": {
-							Description: "Dictates if the values provided should be treated as regular expressions.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-					},
-				},
-			},
+			"filter": dataSourceFilterSchema(),
 			"list": {
 				Description: "This is where Kion makes the discovered data available as a list of resources.",
 				Type:        schema.TypeList,
@@ -116,7 +89,7 @@ func dataSourceAwsCloudformationTemplate() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func dataSourceAwsCloudformationTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -124,13 +97,9 @@ func dataSourceAwsCloudformationTemplateRead(ctx context.Context, d *schema.Reso
 	client := m.(*hc.Client)
 
 	resp := new(hc.CFTListResponseWithOwnersAndTags)
-	err := client.GET("/v3/cft", resp)
+	err := client.GETAllContext(ctx, "/v3/cft", resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read AwsCloudformationTemplate",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read AwsCloudformationTemplate", err)...)
 		return diags
 	}
 
@@ -154,11 +123,7 @@ func dataSourceAwsCloudformationTemplateRead(ctx context.Context, d *schema.Reso
 
 		match, err := f.Match(data)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to filter AwsCloudformationTemplate",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "filter"),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter AwsCloudformationTemplate", err)...)
 			return diags
 		} else if !match {
 			continue
@@ -168,16 +133,16 @@ func dataSourceAwsCloudformationTemplateRead(ctx context.Context, d *schema.Reso
 	}
 
 	if err := d.Set("list", arr); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read AwsCloudformationTemplate",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read AwsCloudformationTemplate", err)...)
 		return diags
 	}
 
-	// Always run.
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set AwsCloudformationTemplate ID", err)...)
+		return diags
+	}
+	d.SetId(id)
 
 	return diags
 }
@@ -2,9 +2,6 @@ package kion
 
 import (
 	"context"
-	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,34 +9,10 @@ import (
 )
 
 func dataSourceAwsIamPolicy() *schema.Resource {
-	return &schema.Resource{
+	return withSingleLookup("list", &schema.Resource{
 		ReadContext: dataSourceAwsIamPolicyRead,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The field name whose values you wish to filter by.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"values": {
-							Description: "The values of the field name you specified.",
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"regex": {
-							Description: "Dictates if the values provided should be treated as regular expressions.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-					},
-				},
-			},
+			"filter": dataSourceFilterSchema(),
 			"list": {
 				Description: "This is where Kion makes the discovered data available as a list of resources.",
 				Type:        schema.TypeList,
@@ -106,7 +79,7 @@ func dataSourceAwsIamPolicy() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func dataSourceAwsIamPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -114,13 +87,9 @@ func dataSourceAwsIamPolicyRead(ctx context.Context, d *schema.ResourceData, m i
 	client := m.(*hc.Client)
 
 	resp := new(hc.IAMPolicyListResponse)
-	err := client.GET("/v3/iam-policy", resp)
+	err := client.GETAllContext(ctx, "/v3/iam-policy", resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read AwsIamPolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read AwsIamPolicy", err)...)
 		return diags
 	}
 
@@ -142,11 +111,7 @@ func dataSourceAwsIamPolicyRead(ctx context.Context, d *schema.ResourceData, m i
 
 		match, err := f.Match(data)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to filter AwsIamPolicy",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "filter"),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter AwsIamPolicy", err)...)
 			return diags
 		} else if !match {
 			continue
@@ -156,16 +121,16 @@ func dataSourceAwsIamPolicyRead(ctx context.Context, d *schema.ResourceData, m i
 	}
 
 	if err := d.Set("list", arr); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read AwsIamPolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read AwsIamPolicy", err)...)
 		return diags
 	}
 
-	// Always run.
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set AwsIamPolicy ID", err)...)
+		return diags
+	}
+	d.SetId(id)
 
 	return diags
 }
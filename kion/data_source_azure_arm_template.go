@@ -2,9 +2,6 @@ package kion
 
 import (
 	"context"
-	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,34 +9,10 @@ import (
 )
 
 func dataSourceAzureArmTemplate() *schema.Resource {
-	return &schema.Resource{
+	return withSingleLookup("list", &schema.Resource{
 		ReadContext: dataSourceAzureArmTemplateRead,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The field name whose values you wish to filter by.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"values": {
-							Description: "The values of the field name you specified.",
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"regex": {
-							Description: "Dictates if the values provided should be treated as regular expressions.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-					},
-				},
-			},
+			"filter": dataSourceFilterSchema(),
 			"list": {
 				Description: "This is where Kion makes the discovered data available as a list of resources.",
 				Type:        schema.TypeList,
@@ -114,7 +87,7 @@ func dataSourceAzureArmTemplate() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func dataSourceAzureArmTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -122,13 +95,9 @@ func dataSourceAzureArmTemplateRead(ctx context.Context, d *schema.ResourceData,
 	client := m.(*hc.Client)
 
 	resp := new(hc.AzureARMTemplateListResponse)
-	err := client.GET("/v3/azure-arm-template", resp)
+	err := client.GETAllContext(ctx, "/v3/azure-arm-template", resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Azure ARM Template",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Azure ARM Template", err)...)
 		return diags
 	}
 
@@ -152,11 +121,7 @@ func dataSourceAzureArmTemplateRead(ctx context.Context, d *schema.ResourceData,
 
 		match, err := f.Match(data)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to filter Azure ARM Template",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "filter"),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter Azure ARM Template", err)...)
 			return diags
 		} else if !match {
 			continue
@@ -166,16 +131,16 @@ func dataSourceAzureArmTemplateRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if err := d.Set("list", arr); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Azure ARM Template",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Azure ARM Template", err)...)
 		return diags
 	}
 
-	// Always run.
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set Azure ARM Template ID", err)...)
+		return diags
+	}
+	d.SetId(id)
 
 	return diags
 }
@@ -2,9 +2,6 @@ package kion
 
 import (
 	"context"
-	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,34 +9,10 @@ import (
 )
 
 func dataSourceAzurePolicy() *schema.Resource {
-	return &schema.Resource{
+	return withSingleLookup("list", &schema.Resource{
 		ReadContext: dataSourceAzurePolicyRead,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The field name whose values you wish to filter by.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"values": {
-							Description: "The values of the field name you specified.",
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"regex": {
-							Description: "Dictates if the values provided should be treated as regular expressions.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-					},
-				},
-			},
+			"filter": dataSourceFilterSchema(),
 			"list": {
 				Description: "This is where Kion makes the discovered data available as a list of resources.",
 				Type:        schema.TypeList,
@@ -102,7 +75,7 @@ func dataSourceAzurePolicy() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func dataSourceAzurePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -110,13 +83,9 @@ func dataSourceAzurePolicyRead(ctx context.Context, d *schema.ResourceData, m in
 	client := m.(*hc.Client)
 
 	resp := new(hc.AzurePolicyListResponse)
-	err := client.GET("/v3/azure-policy", resp)
+	err := client.GETAllContext(ctx, "/v3/azure-policy", resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read AzurePolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read AzurePolicy", err)...)
 		return diags
 	}
 
@@ -137,11 +106,7 @@ func dataSourceAzurePolicyRead(ctx context.Context, d *schema.ResourceData, m in
 
 		match, err := f.Match(data)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to filter AzurePolicy",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "filter"),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter AzurePolicy", err)...)
 			return diags
 		} else if !match {
 			continue
@@ -151,16 +116,16 @@ func dataSourceAzurePolicyRead(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if err := d.Set("list", arr); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read AzurePolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read AzurePolicy", err)...)
 		return diags
 	}
 
-	// Always run.
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set AzurePolicy ID", err)...)
+		return diags
+	}
+	d.SetId(id)
 
 	return diags
 }
@@ -3,8 +3,6 @@ package kion
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,34 +10,10 @@ import (
 )
 
 func dataSourceCloudRule() *schema.Resource {
-	return &schema.Resource{
+	return withSingleLookup("list", &schema.Resource{
 		ReadContext: dataSourceCloudRuleRead,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The field name whose values you wish to filter by.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"values": {
-							Description: "The values of the field name you specified.",
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"regex": {
-							Description: "Dictates if the values provided should be treated as regular expressions.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-					},
-				},
-			},
+			"filter": dataSourceFilterSchema(),
 			"list": {
 				Description: "This is where Kion makes the discovered data available as a list of resources.",
 				Type:        schema.TypeList,
@@ -58,6 +32,12 @@ func dataSourceCloudRule() *schema.Resource {
 							Type:     schema.TypeInt,
 							Computed: true,
 						},
+						"labels": {
+							Description: "The labels associated with the item. Filter on them with a name such as labels.env.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
 						"name": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -76,7 +56,7 @@ func dataSourceCloudRule() *schema.Resource {
 				},
 			},
 		},
-	}
+	}, ownerDetails(readCloudRuleOwners))
 }
 
 func dataSourceCloudRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -84,13 +64,9 @@ func dataSourceCloudRuleRead(ctx context.Context, d *schema.ResourceData, m inte
 	client := m.(*hc.Client)
 
 	resp := new(hc.CloudRuleListResponse)
-	err := client.GET("/v3/cloud-rule", resp)
+	err := client.GETAllContext(ctx, "/v3/cloud-rule", resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read CloudRule",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read CloudRule", err)...)
 		return diags
 	}
 
@@ -110,13 +86,9 @@ func dataSourceCloudRuleRead(ctx context.Context, d *schema.ResourceData, m inte
 			data["pre_webhook_id"] = item.PreWebhookID
 		}
 
-		match, err := f.Match(data)
+		match, err := matchWithLabels(ctx, d, client, f, data, "cloud-rule", item.ID)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to filter CloudRule",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "filter"),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter CloudRule", err)...)
 			return diags
 		} else if !match {
 			continue
@@ -126,16 +98,25 @@ func dataSourceCloudRuleRead(ctx context.Context, d *schema.ResourceData, m inte
 	}
 
 	if err := d.Set("list", arr); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read CloudRule",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read CloudRule", err)...)
 		return diags
 	}
 
-	// Always run.
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set CloudRule ID", err)...)
+		return diags
+	}
+	d.SetId(id)
 
 	return diags
 }
+
+// readCloudRuleOwners returns the owners of a cloud rule.
+func readCloudRuleOwners(ctx context.Context, client *hc.Client, id string) ([]interface{}, []interface{}, error) {
+	resp := new(hc.CloudRuleResponse)
+	if err := client.GETContext(ctx, fmt.Sprintf("/v3/cloud-rule/%s", id), resp); err != nil {
+		return nil, nil, err
+	}
+	return hc.InflateObjectWithID(resp.Data.OwnerUsers), hc.InflateObjectWithID(resp.Data.OwnerUserGroups), nil
+}
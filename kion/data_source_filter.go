@@ -0,0 +1,82 @@
+package kion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+// dataSourceFilterSchema returns the filter block shared by all data
+// sources. Filters are combined with AND; the values of a filter with OR,
+// except for not_equals which requires the field to differ from all values.
+func dataSourceFilterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The field name whose values you wish to filter by.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"values": {
+					Description: "The values of the field name you specified. Not used by the exists and empty operators.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"regex": {
+					Description: "Dictates if the values provided should be treated as regular expressions. Only used by the equals and not_equals operators.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"operator": {
+					Description:  "How the field is compared to the values: equals, not_equals, contains, prefix, gt, lt, exists or empty. gt and lt compare numbers, such as IDs and datecodes, numerically and other values as strings. Defaults to equals.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      hc.FilterEquals,
+					ValidateFunc: validation.StringInSlice(hc.FilterOperators, false),
+				},
+				"array_match": {
+					Description:  "Whether any or all items of an array, such as owner_users in owner_users.id, must match. Defaults to any. not_equals always requires every item to differ from the values.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      hc.FilterMatchAny,
+					ValidateFunc: validation.StringInSlice([]string{hc.FilterMatchAny, hc.FilterMatchAll}, false),
+				},
+			},
+		},
+	}
+}
+
+// matchWithLabels reports whether an item of a list data source matches the
+// id and name arguments and f. The labels of the item are added to data as
+// "labels"; they are only read from Kion for items that match the arguments
+// and the filters on the other fields.
+func matchWithLabels(ctx context.Context, d *schema.ResourceData, client *hc.Client, f *hc.Filterable, data map[string]interface{}, resourceType string, id interface{}) (bool, error) {
+	if !matchesArguments(lookupArguments(d), data) {
+		return false, nil
+	}
+
+	labelFilters, others := f.Partition("labels")
+	match, err := others.Match(data)
+	if err != nil || !match {
+		return false, err
+	}
+
+	labels := make(map[string]interface{})
+	if client.RequireVersion(hc.VersionLabels, "Labels") == nil {
+		labels, err = hc.ReadResourceLabels(ctx, client, resourceType, fmt.Sprint(id))
+		if err != nil {
+			return false, err
+		}
+	}
+	data["labels"] = labels
+
+	return labelFilters.Match(data)
+}
@@ -3,8 +3,6 @@ package kion
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,34 +10,10 @@ import (
 )
 
 func dataSourceFundingSource() *schema.Resource {
-	return &schema.Resource{
+	return withSingleLookup("list", &schema.Resource{
 		ReadContext: dataSourceFundingSourceRead,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The field name whose values you wish to filter by.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"values": {
-							Description: "The values of the field name you specified.",
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"regex": {
-							Description: "Dictates if the values provided should be treated as regular expressions.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-					},
-				},
-			},
+			"filter": dataSourceFilterSchema(),
 			"list": {
 				Description: "This is where Kion makes the discovered data available as a list of resources.",
 				Type:        schema.TypeList,
@@ -54,6 +28,16 @@ func dataSourceFundingSource() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"labels": {
+							Description: "The labels associated with the item. Filter on them with a name such as labels.env.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
 						"name": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -74,7 +58,7 @@ func dataSourceFundingSource() *schema.Resource {
 				},
 			},
 		},
-	}
+	}, ownerDetails(readFundingSourceOwners))
 }
 
 func dataSourceFundingSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -82,13 +66,9 @@ func dataSourceFundingSourceRead(ctx context.Context, d *schema.ResourceData, m
 	client := m.(*hc.Client)
 
 	resp := new(hc.FundingSourceListResponse)
-	err := client.GET("/v3/funding-source", resp)
+	err := client.GETAllContext(ctx, "/v3/funding-source", resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Funding Source",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Funding Source", err)...)
 		return diags
 	}
 
@@ -99,18 +79,15 @@ func dataSourceFundingSourceRead(ctx context.Context, d *schema.ResourceData, m
 		data := make(map[string]interface{})
 		data["amount"] = item.Amount
 		data["description"] = item.Description
+		data["id"] = item.ID
 		data["name"] = item.Name
 		data["ou_id"] = item.OUID
 		data["start_datecode"] = item.StartDatecode
 		data["end_datecode"] = item.EndDatecode
 
-		match, err := f.Match(data)
+		match, err := matchWithLabels(ctx, d, client, f, data, "funding-source", item.ID)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to filter Funding Source",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "filter"),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter Funding Source", err)...)
 			return diags
 		} else if !match {
 			continue
@@ -120,16 +97,21 @@ func dataSourceFundingSourceRead(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if err := d.Set("list", arr); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Funding Source",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Funding Source", err)...)
 		return diags
 	}
 
-	// Always run.
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set Funding Source ID", err)...)
+		return diags
+	}
+	d.SetId(id)
 
 	return diags
 }
+
+// readFundingSourceOwners returns the owners of a funding source.
+func readFundingSourceOwners(ctx context.Context, client *hc.Client, id string) ([]interface{}, []interface{}, error) {
+	return readPermissionMappingOwners(ctx, client, fmt.Sprintf("/v3/funding-source/%s/permission-mapping", id))
+}
@@ -2,9 +2,6 @@ package kion
 
 import (
 	"context"
-	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,34 +9,10 @@ import (
 )
 
 func dataSourceGcpIamRole() *schema.Resource {
-	return &schema.Resource{
+	return withSingleLookup("list", &schema.Resource{
 		ReadContext: dataSourceGcpIamRoleRead,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The field name whose values you wish to filter by.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"values": {
-							Description: "The values of the field name you specified.",
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"regex": {
-							Description: "Dictates if the values provided should be treated as regular expressions.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-					},
-				},
-			},
+			"filter": dataSourceFilterSchema(),
 			"list": {
 				Description: "This is where Kion makes the discovered data available as a list of resources.",
 				Type:        schema.TypeList,
@@ -107,7 +80,7 @@ func dataSourceGcpIamRole() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func dataSourceGcpIamRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -115,13 +88,9 @@ func dataSourceGcpIamRoleRead(ctx context.Context, d *schema.ResourceData, m int
 	client := m.(*hc.Client)
 
 	resp := new(hc.GCPRoleListResponseWithOwners)
-	err := client.GET("/v3/gcp-iam-role", resp)
+	err := client.GETAllContext(ctx, "/v3/gcp-iam-role", resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read (list) GcpIamRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read (list) GcpIamRole", err)...)
 		return diags
 	}
 
@@ -144,11 +113,7 @@ func dataSourceGcpIamRoleRead(ctx context.Context, d *schema.ResourceData, m int
 
 		match, err := f.Match(data)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to filter GcpIamRole",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "filter"),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter GcpIamRole", err)...)
 			return diags
 		} else if !match {
 			continue
@@ -158,16 +123,16 @@ func dataSourceGcpIamRoleRead(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	if err := d.Set("list", arr); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read GcpIamRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read GcpIamRole", err)...)
 		return diags
 	}
 
-	// Always run.
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set GcpIamRole ID", err)...)
+		return diags
+	}
+	d.SetId(id)
 
 	return diags
 }
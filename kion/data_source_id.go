@@ -0,0 +1,36 @@
+package kion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceInputs are the arguments data sources may take. Arguments a
+// data source doesn't have are ignored.
+var dataSourceInputs = []string{"filter", "ou_id", "project_id"}
+
+// dataSourceID derives the ID of a data source from its arguments, e.g. its
+// filters, and the items it found, so the ID only changes when one of them
+// does.
+func dataSourceID(d *schema.ResourceData, results interface{}) (string, error) {
+	inputs := make(map[string]interface{})
+	for _, key := range dataSourceInputs {
+		if v, ok := d.GetOk(key); ok {
+			inputs[key] = v
+		}
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"inputs":  inputs,
+		"results": results,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
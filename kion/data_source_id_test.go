@@ -0,0 +1,33 @@
+package kion
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceID(t *testing.T) {
+	config := map[string]interface{}{
+		"filter": []interface{}{
+			map[string]interface{}{"name": "name", "values": []interface{}{"prod"}},
+		},
+	}
+	items := []map[string]interface{}{{"id": 1, "name": "prod"}}
+
+	id, err := dataSourceID(schema.TestResourceDataRaw(t, dataSourceOU().Schema, config), items)
+	assert.NoError(t, err)
+	assert.Len(t, id, 64)
+
+	again, err := dataSourceID(schema.TestResourceDataRaw(t, dataSourceOU().Schema, config), items)
+	assert.NoError(t, err)
+	assert.Equal(t, id, again)
+
+	other, err := dataSourceID(schema.TestResourceDataRaw(t, dataSourceOU().Schema, config), []map[string]interface{}{{"id": 2, "name": "prod"}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, other)
+
+	other, err = dataSourceID(schema.TestResourceDataRaw(t, dataSourceOU().Schema, map[string]interface{}{}), items)
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, other)
+}
@@ -3,8 +3,6 @@ package kion
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,34 +10,10 @@ import (
 )
 
 func dataSourceOU() *schema.Resource {
-	return &schema.Resource{
+	return withSingleLookup("list", &schema.Resource{
 		ReadContext: dataSourceOURead,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The field name whose values you wish to filter by.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"values": {
-							Description: "The values of the field name you specified.",
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"regex": {
-							Description: "Dictates if the values provided should be treated as regular expressions.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-					},
-				},
-			},
+			"filter": dataSourceFilterSchema(),
 			"list": {
 				Description: "This is where Kion makes the discovered data available as a list of resources.",
 				Type:        schema.TypeList,
@@ -58,6 +32,12 @@ func dataSourceOU() *schema.Resource {
 							Type:     schema.TypeInt,
 							Computed: true,
 						},
+						"labels": {
+							Description: "The labels associated with the item. Filter on them with a name such as labels.env.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
 						"name": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -74,7 +54,7 @@ func dataSourceOU() *schema.Resource {
 				},
 			},
 		},
-	}
+	}, ownerDetails(readOUOwners))
 }
 
 func dataSourceOURead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -82,13 +62,9 @@ func dataSourceOURead(ctx context.Context, d *schema.ResourceData, m interface{}
 	client := m.(*hc.Client)
 
 	resp := new(hc.OUListResponse)
-	err := client.GET("/v3/ou", resp)
+	err := client.GETAllContext(ctx, "/v3/ou", resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read OU",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read OU", err)...)
 		return diags
 	}
 
@@ -104,13 +80,9 @@ func dataSourceOURead(ctx context.Context, d *schema.ResourceData, m interface{}
 		data["parent_ou_id"] = item.ParentOuID
 		data["permission_scheme_id"] = item.PermissionSchemeID
 
-		match, err := f.Match(data)
+		match, err := matchWithLabels(ctx, d, client, f, data, "ou", item.ID)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to filter OU",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "filter"),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter OU", err)...)
 			return diags
 		} else if !match {
 			continue
@@ -120,16 +92,25 @@ func dataSourceOURead(ctx context.Context, d *schema.ResourceData, m interface{}
 	}
 
 	if err := d.Set("list", arr); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read OU",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read OU", err)...)
 		return diags
 	}
 
-	// Always run.
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set OU ID", err)...)
+		return diags
+	}
+	d.SetId(id)
 
 	return diags
 }
+
+// readOUOwners returns the owners of an OU.
+func readOUOwners(ctx context.Context, client *hc.Client, id string) ([]interface{}, []interface{}, error) {
+	resp := new(hc.OUResponse)
+	if err := client.GETContext(ctx, fmt.Sprintf("/v3/ou/%s", id), resp); err != nil {
+		return nil, nil, err
+	}
+	return hc.InflateObjectWithID(resp.Data.OwnerUsers), hc.InflateObjectWithID(resp.Data.OwnerUserGroups), nil
+}
@@ -0,0 +1,99 @@
+package kion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+func dataSourceOUPermissionMapping() *schema.Resource {
+	return withSingleLookup("list", &schema.Resource{
+		ReadContext: dataSourceOUPermissionMappingRead,
+		Schema: map[string]*schema.Schema{
+			"filter": dataSourceFilterSchema(),
+			"ou_id": {
+				Description: "The ID of the OU to read the permission mappings of.",
+				Type:        schema.TypeInt,
+				Required:    true,
+			},
+			"list": {
+				Description: "This is where Kion makes the discovered data available as a list of resources.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"app_role_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"user_group_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+						"user_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+func dataSourceOUPermissionMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+
+	resp := new(hc.OUPermissionMappingListResponse)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/ou/%d/permission-mapping", d.Get("ou_id").(int)), resp)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read OU permission mapping", err)...)
+		return diags
+	}
+
+	f := hc.NewFilterable(d)
+
+	arr := make([]map[string]interface{}, 0)
+	for _, item := range resp.Data {
+		data := make(map[string]interface{})
+		data["app_role_id"] = item.AppRoleID
+		data["user_group_ids"] = make([]int, 0)
+		if item.UserGroupIds != nil {
+			data["user_group_ids"] = *item.UserGroupIds
+		}
+		data["user_ids"] = make([]int, 0)
+		if item.UserIds != nil {
+			data["user_ids"] = *item.UserIds
+		}
+
+		match, err := f.Match(data)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter OU permission mapping", err)...)
+			return diags
+		} else if !match {
+			continue
+		}
+
+		arr = append(arr, data)
+	}
+
+	if err := d.Set("list", arr); err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read OU permission mapping", err)...)
+		return diags
+	}
+
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set OU permission mapping ID", err)...)
+		return diags
+	}
+	d.SetId(id)
+
+	return diags
+}
@@ -3,8 +3,6 @@ package kion
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,34 +10,10 @@ import (
 )
 
 func dataSourceProject() *schema.Resource {
-	return &schema.Resource{
+	return withSingleLookup("list", &schema.Resource{
 		ReadContext: dataSourceProjectRead,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The field name whose values you wish to filter by.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"values": {
-							Description: "The values of the field name you specified.",
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"regex": {
-							Description: "Dictates if the values provided should be treated as regular expressions.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-					},
-				},
-			},
+			"filter": dataSourceFilterSchema(),
 			"list": {
 				Description: "This is where Kion makes the discovered data available as a list of resources.",
 				Type:        schema.TypeList,
@@ -66,6 +40,12 @@ func dataSourceProject() *schema.Resource {
 							Type:     schema.TypeInt,
 							Computed: true,
 						},
+						"labels": {
+							Description: "The labels associated with the item. Filter on them with a name such as labels.env.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
 						"name": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -78,7 +58,7 @@ func dataSourceProject() *schema.Resource {
 				},
 			},
 		},
-	}
+	}, ownerDetails(readProjectOwners))
 }
 
 func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -86,13 +66,9 @@ func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interf
 	client := m.(*hc.Client)
 
 	resp := new(hc.ProjectListResponse)
-	err := client.GET("/v3/project", resp)
+	err := client.GETAllContext(ctx, "/v3/project", resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Project",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Project", err)...)
 		return diags
 	}
 
@@ -109,13 +85,9 @@ func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interf
 		data["name"] = item.Name
 		data["ou_id"] = item.OUID
 
-		match, err := f.Match(data)
+		match, err := matchWithLabels(ctx, d, client, f, data, "project", item.ID)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to filter Project",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "filter"),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter Project", err)...)
 			return diags
 		} else if !match {
 			continue
@@ -125,16 +97,21 @@ func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if err := d.Set("list", arr); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Project",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), "all"),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Project", err)...)
 		return diags
 	}
 
-	// Always run.
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set Project ID", err)...)
+		return diags
+	}
+	d.SetId(id)
 
 	return diags
 }
+
+// readProjectOwners returns the owners of a project.
+func readProjectOwners(ctx context.Context, client *hc.Client, id string) ([]interface{}, []interface{}, error) {
+	return readPermissionMappingOwners(ctx, client, fmt.Sprintf("/v3/project/%s/permission-mapping", id))
+}
@@ -2,9 +2,6 @@ package kion
 
 import (
 	"context"
-	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,34 +9,10 @@ import (
 )
 
 func dataSourceProjectEnforcement() *schema.Resource {
-	return &schema.Resource{
+	return withSingleLookup("enforcements", &schema.Resource{
 		ReadContext: dataSourceProjectEnforcementRead,
 		Schema: map[string]*schema.Schema{
-			"filter": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The field name whose values you wish to filter by.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"values": {
-							Description: "The values of the field name you specified.",
-							Type:        schema.TypeList,
-							Required:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						"regex": {
-							Description: "Dictates if the values provided should be treated as regular expressions.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-					},
-				},
-			},
+			"filter": dataSourceFilterSchema(),
 			"enforcements": {
 				Description: "List of project enforcement policies configured in the system.",
 				Type:        schema.TypeList,
@@ -116,7 +89,7 @@ func dataSourceProjectEnforcement() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func dataSourceProjectEnforcementRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -124,13 +97,9 @@ func dataSourceProjectEnforcementRead(ctx context.Context, d *schema.ResourceDat
 	client := m.(*hc.Client)
 
 	resp := new(hc.ProjectEnforcementResponse)
-	err := client.GET("/v3/project/{id}/enforcement", resp)
+	err := client.GETAllContext(ctx, "/v3/project/{id}/enforcement", resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Project Enforcement",
-			Detail:   fmt.Sprintf("Error: %v", err.Error()),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Project Enforcement", err)...)
 		return diags
 	}
 
@@ -157,11 +126,7 @@ func dataSourceProjectEnforcementRead(ctx context.Context, d *schema.ResourceDat
 
 		match, err := f.Match(data)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to filter Project Enforcement",
-				Detail:   fmt.Sprintf("Error: %v", err.Error()),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter Project Enforcement", err)...)
 			return diags
 		} else if !match {
 			continue
@@ -171,16 +136,16 @@ func dataSourceProjectEnforcementRead(ctx context.Context, d *schema.ResourceDat
 	}
 
 	if err := d.Set("enforcements", enforcements); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set Project Enforcement data",
-			Detail:   fmt.Sprintf("Error: %v", err.Error()),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set Project Enforcement data", err)...)
 		return diags
 	}
 
-	// Set the ID of the datasource to a unique value, which is the current timestamp
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	id, err := dataSourceID(d, enforcements)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set Project Enforcement ID", err)...)
+		return diags
+	}
+	d.SetId(id)
 
 	return diags
 }
@@ -0,0 +1,213 @@
+package kion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+// singleItemDetails adds attributes to the item matched in single object mode
+// that are too expensive to read for every item of a list, such as its
+// owners. The attributes in schema are only exposed at the top level.
+type singleItemDetails struct {
+	schema map[string]*schema.Schema
+	read   func(ctx context.Context, client *hc.Client, item map[string]interface{}) error
+}
+
+// withSingleLookup adds a single object mode to a list data source. When
+// exactly_one is true, or the name or id argument is set, the data source
+// fails unless exactly one item of listAttr matches, and the attributes of
+// that item, including the ones added by details, are also exposed at the top
+// level.
+func withSingleLookup(listAttr string, r *schema.Resource, details ...singleItemDetails) *schema.Resource {
+	item := r.Schema[listAttr].Elem.(*schema.Resource).Schema
+
+	r.Schema["exactly_one"] = &schema.Schema{
+		Description: "If true, the data source fails unless exactly one item matches the filters, and the attributes of that item are available at the top level. Implied by name and id.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+
+	var fields []string
+	for name, s := range item {
+		if _, ok := r.Schema[name]; ok {
+			continue
+		}
+		fields = append(fields, name)
+
+		switch {
+		case name == "id":
+			// The ID of a single object data source is the ID of the item.
+			r.Schema[name] = &schema.Schema{
+				Description: "The ID of the item to look up. Implies exactly_one.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			}
+		case name == "name" && s.Type == schema.TypeString:
+			r.Schema[name] = &schema.Schema{
+				Description: "The name of the item to look up. Implies exactly_one.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			}
+		default:
+			description := s.Description
+			if description == "" {
+				description = fmt.Sprintf("The %s attribute of the matched item. Only set in single object mode.", name)
+			}
+			r.Schema[name] = &schema.Schema{
+				Description: description,
+				Type:        s.Type,
+				Elem:        s.Elem,
+				Computed:    true,
+				Sensitive:   s.Sensitive,
+			}
+		}
+	}
+
+	for _, detail := range details {
+		for name, s := range detail.schema {
+			fields = append(fields, name)
+			r.Schema[name] = &schema.Schema{
+				Description: fmt.Sprintf("The %s attribute of the matched item. Only set in single object mode.", name),
+				Type:        s.Type,
+				Elem:        s.Elem,
+				Computed:    true,
+			}
+		}
+	}
+
+	read := r.ReadContext
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		// Reading the list sets the ID of the data source, so the arguments
+		// are captured first.
+		args := lookupArguments(d)
+		diags := read(ctx, d, m)
+		if diags.HasError() || !singleLookup(d, args) {
+			return diags
+		}
+		client, _ := m.(*hc.Client)
+		return append(diags, setSingleItem(ctx, d, client, listAttr, fields, args, details...)...)
+	}
+
+	return r
+}
+
+// singleLookup reports whether the data source is in single object mode.
+func singleLookup(d *schema.ResourceData, args map[string]string) bool {
+	return d.Get("exactly_one").(bool) || len(args) > 0
+}
+
+// lookupArguments returns the id and name arguments set in the
+// configuration.
+func lookupArguments(d *schema.ResourceData) map[string]string {
+	args := make(map[string]string)
+	for _, arg := range []string{"id", "name"} {
+		if v, ok := d.GetOk(arg); ok && isConfigured(d, arg) {
+			if s, ok := v.(string); ok && s != "" {
+				args[arg] = s
+			}
+		}
+	}
+	return args
+}
+
+// isConfigured reports whether an Optional and Computed argument was set in
+// the configuration rather than computed by a previous read.
+func isConfigured(d *schema.ResourceData, arg string) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().HasAttribute(arg) {
+		return true
+	}
+	return !raw.GetAttr(arg).IsNull()
+}
+
+// setSingleItem narrows the items of listAttr down to the one matching the
+// id and name arguments, reads its details and copies its fields to the top
+// level.
+func setSingleItem(ctx context.Context, d *schema.ResourceData, client *hc.Client, listAttr string, fields []string, args map[string]string, details ...singleItemDetails) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var matched []interface{}
+	for _, v := range d.Get(listAttr).([]interface{}) {
+		item := v.(map[string]interface{})
+		if !matchesArguments(args, item) {
+			continue
+		}
+		matched = append(matched, item)
+	}
+
+	if len(matched) != 1 {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Data source did not match exactly one item",
+			Detail:        fmt.Sprintf("Expected exactly one item to match the id, name and filter arguments, found %d. Refine the arguments, or remove id, name and exactly_one to return a list of all matches.", len(matched)),
+			AttributePath: cty.GetAttrPath("filter"),
+		})
+		return diags
+	}
+
+	item := matched[0].(map[string]interface{})
+	for _, detail := range details {
+		if err := detail.read(ctx, client, item); err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read matched item", err)...)
+			return diags
+		}
+	}
+
+	// The list items don't have the attributes only read for the matched item.
+	listItem := make(map[string]interface{})
+	for k, v := range item {
+		if !isDetail(k, details) {
+			listItem[k] = v
+		}
+	}
+	if err := d.Set(listAttr, []interface{}{listItem}); err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set matched item", err)...)
+		return diags
+	}
+
+	for _, field := range fields {
+		value := item[field]
+		if field == "id" {
+			value = fmt.Sprint(value)
+		}
+		if err := d.Set(field, value); err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to set matched item", err)...)
+			return diags
+		}
+	}
+
+	if id, ok := item["id"]; ok {
+		d.SetId(fmt.Sprint(id))
+	}
+
+	return diags
+}
+
+// isDetail reports whether field is only read for the matched item.
+func isDetail(field string, details []singleItemDetails) bool {
+	for _, detail := range details {
+		if _, ok := detail.schema[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesArguments reports whether the fields of item equal the id and name
+// arguments in args.
+func matchesArguments(args map[string]string, item map[string]interface{}) bool {
+	for arg, value := range args {
+		if fmt.Sprint(item[arg]) != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package kion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSingleItem(t *testing.T) {
+	r := dataSourceOU()
+	fields := []string{"created_at", "description", "id", "labels", "name", "parent_ou_id", "permission_scheme_id"}
+	items := []interface{}{
+		map[string]interface{}{"id": 1, "name": "prod", "parent_ou_id": 0, "labels": map[string]interface{}{"env": "prod"}},
+		map[string]interface{}{"id": 2, "name": "dev", "parent_ou_id": 1},
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"exactly_one": true})
+	assert.True(t, singleLookup(d, lookupArguments(d)))
+	assert.NoError(t, d.Set("list", items))
+	diags := setSingleItem(context.Background(), d, nil, "list", fields, lookupArguments(d))
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail, "found 2")
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "prod"})
+	assert.True(t, singleLookup(d, lookupArguments(d)))
+	assert.NoError(t, d.Set("list", items))
+	diags = setSingleItem(context.Background(), d, nil, "list", fields, lookupArguments(d))
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "1", d.Id())
+	assert.Equal(t, "1", d.Get("id"))
+	assert.Equal(t, "prod", d.Get("name"))
+	assert.Equal(t, map[string]interface{}{"env": "prod"}, d.Get("labels"))
+	assert.Len(t, d.Get("list"), 1)
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"id": "3"})
+	assert.NoError(t, d.Set("list", items))
+	assert.True(t, setSingleItem(context.Background(), d, nil, "list", fields, lookupArguments(d)).HasError())
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	assert.False(t, singleLookup(d, lookupArguments(d)))
+}
+
+func TestSingleItemDetails(t *testing.T) {
+	var requests []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v3/project":
+			_, _ = w.Write([]byte(`{"status": 200, "data": [{"id": 1, "name": "prod", "ou_id": 3}, {"id": 2, "name": "dev", "ou_id": 3}]}`))
+		case "/api/v3/project/1/permission-mapping":
+			_, _ = w.Write([]byte(`{"status": 200, "data": [
+				{"app_role_id": 1, "user_ids": [4], "user_groups_ids": [5]},
+				{"app_role_id": 2, "user_ids": [6]}
+			]}`))
+		case "/api/v3/project/1/labels":
+			_, _ = w.Write([]byte(`{"status": 200, "data": [{"key": "env", "value": "prod"}]}`))
+		case "/api/v3/project/2/labels":
+			_, _ = w.Write([]byte(`{"status": 200, "data": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	r := dataSourceProject()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "prod"})
+	diags := r.ReadContext(context.Background(), d, client)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "1", d.Id())
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": 4}}, d.Get("owner_users"))
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": 5}}, d.Get("owner_user_groups"))
+	assert.Equal(t, map[string]interface{}{"env": "prod"}, d.Get("labels"))
+
+	// Only the owners and labels of the matched item are read.
+	assert.NotContains(t, requests, "/api/v3/project/2/permission-mapping")
+	assert.NotContains(t, requests, "/api/v3/project/2/labels")
+
+	// In list mode the labels of every item are read.
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	diags = r.ReadContext(context.Background(), d, client)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, map[string]interface{}{"env": "prod"}, d.Get("list.0.labels"))
+	assert.Contains(t, requests, "/api/v3/project/2/labels")
+}
@@ -0,0 +1,153 @@
+package kion
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+func dataSourceWebhook() *schema.Resource {
+	return withSingleLookup("list", &schema.Resource{
+		ReadContext: dataSourceWebhookRead,
+		Schema: map[string]*schema.Schema{
+			"filter": dataSourceFilterSchema(),
+			"list": {
+				Description: "This is where Kion makes the discovered data available as a list of resources.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"callout_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner_user_groups": {
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+							Type:     schema.TypeList,
+							Computed: true,
+						},
+						"owner_users": {
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+							Type:     schema.TypeList,
+							Computed: true,
+						},
+						"request_body": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"request_headers": {
+							Type:     schema.TypeMap,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+						"request_method": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"should_send_secure_info": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"skip_ssl": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"timeout_in_seconds": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+func dataSourceWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+
+	resp := new(hc.WebhookListResponse)
+	err := client.GETAllContext(ctx, "/v3/webhook", resp)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read (list) Webhook", err)...)
+		return diags
+	}
+
+	f := hc.NewFilterable(d)
+
+	arr := make([]map[string]interface{}, 0)
+	for _, item := range resp.Data {
+		headers, err := inflateWebhookHeaders(item.Webhook.RequestHeaders)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read (list) Webhook", err)...)
+			return diags
+		}
+
+		data := make(map[string]interface{})
+		data["callout_url"] = item.Webhook.CalloutURL
+		data["description"] = item.Webhook.Description
+		data["id"] = item.Webhook.ID
+		data["name"] = item.Webhook.Name
+		data["owner_user_groups"] = hc.InflateObjectWithID(item.OwnerUserGroups)
+		data["owner_users"] = hc.InflateObjectWithID(item.OwnerUsers)
+		data["request_body"] = item.Webhook.RequestBody
+		data["request_headers"] = headers
+		data["request_method"] = item.Webhook.RequestMethod
+		data["should_send_secure_info"] = item.Webhook.ShouldSendSecureInfo
+		data["skip_ssl"] = item.Webhook.SkipSSL
+		data["timeout_in_seconds"] = item.Webhook.TimeoutInSeconds
+
+		match, err := f.Match(data)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to filter Webhook", err)...)
+			return diags
+		} else if !match {
+			continue
+		}
+
+		arr = append(arr, data)
+	}
+
+	if err := d.Set("list", arr); err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Webhook", err)...)
+		return diags
+	}
+
+	id, err := dataSourceID(d, arr)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set Webhook ID", err)...)
+		return diags
+	}
+	d.SetId(id)
+
+	return diags
+}
@@ -0,0 +1,115 @@
+package kion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+// kionFieldAliases maps request fields of the Kion API to the attribute names
+// resources use for them when the two differ.
+var kionFieldAliases = map[string]string{
+	"owner_user_ids":       "owner_users",
+	"owner_user_group_ids": "owner_user_groups",
+}
+
+// apiErrorDiagnostics converts an error returned by the Kion client into
+// diagnostics. Field errors reported by Kion are attached to the matching
+// attribute so Terraform shows them next to the offending configuration.
+// d may be nil when no resource configuration is available.
+func apiErrorDiagnostics(d *schema.ResourceData, summary string, err error) diag.Diagnostics {
+	apiErr, ok := hc.AsAPIError(err)
+	if !ok {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   err.Error(),
+		}}
+	}
+
+	var diags diag.Diagnostics
+	if apiErr.Message != "" || len(apiErr.FieldErrors) == 0 {
+		message := apiErr.Message
+		if message == "" {
+			message = "no error message returned"
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("%s\n\nKion responded with status %d to %s %s.", message, apiErr.StatusCode, apiErr.Method, apiErr.URL),
+		})
+	}
+
+	for _, fieldErr := range apiErr.FieldErrors {
+		diagnostic := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("%s: %s", fieldErr.Field, fieldErr.Message),
+		}
+		if attr, ok := configAttribute(d, fieldErr.Field); ok {
+			diagnostic.Detail = fmt.Sprintf("%s: %s", attr, fieldErr.Message)
+			diagnostic.AttributePath = cty.GetAttrPath(attr)
+		}
+		diags = append(diags, diagnostic)
+	}
+
+	return diags
+}
+
+// configAttribute returns the top level attribute of the resource
+// configuration that corresponds to a Kion request field.
+func configAttribute(d *schema.ResourceData, field string) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+
+	ty := d.GetRawConfig().Type()
+	if !ty.IsObjectType() {
+		return "", false
+	}
+
+	field = strings.SplitN(field, ".", 2)[0]
+	for _, name := range []string{field, kionFieldAliases[field]} {
+		if name != "" && ty.HasAttribute(name) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// readImportedState reads a resource being imported with read. The import
+// fails when read returns errors or does not find the object, instead of
+// importing an empty state.
+func readImportedState(ctx context.Context, d *schema.ResourceData, m interface{}, read schema.ReadContextFunc) ([]*schema.ResourceData, error) {
+	ID := d.Id()
+	if diags := read(ctx, d, m); diags.HasError() {
+		return nil, diagnosticsError(diags)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("unable to import %q: the object was not found in Kion", ID)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// diagnosticsError combines the errors in diags into a single error.
+func diagnosticsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		if d.Detail == "" {
+			errs = append(errs, errors.New(d.Summary))
+		} else {
+			errs = append(errs, fmt.Errorf("%s: %s", d.Summary, d.Detail))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,90 @@
+package kion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	err := hc.NewRequestError(422, &hc.APIError{
+		StatusCode: 422,
+		Method:     "POST",
+		URL:        "/v3/ou",
+		FieldErrors: []hc.FieldError{
+			{Field: "permission_scheme_id", Message: "scheme 99 not found"},
+		},
+	})
+
+	// Without a configuration the field error can't be attached to an attribute.
+	diags := apiErrorDiagnostics(nil, "Unable to create OU", err)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "permission_scheme_id: scheme 99 not found", diags[0].Detail)
+	assert.Nil(t, diags[0].AttributePath)
+
+	diags = apiErrorDiagnostics(nil, "Unable to create OU", errors.New("boom"))
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "boom", diags[0].Detail)
+}
+
+func TestAPIErrorDiagnosticsAttributePath(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceOU().Schema, map[string]interface{}{
+		"name":                 "ou",
+		"parent_ou_id":         0,
+		"permission_scheme_id": 99,
+	})
+
+	err := hc.NewRequestError(422, &hc.APIError{
+		StatusCode: 422,
+		Method:     "POST",
+		URL:        "/v3/ou",
+		Message:    "validation failed",
+		FieldErrors: []hc.FieldError{
+			{Field: "permission_scheme_id", Message: "scheme 99 not found"},
+			{Field: "owner_user_ids", Message: "user 5 not found"},
+			{Field: "unknown_field", Message: "is invalid"},
+		},
+	})
+
+	diags := apiErrorDiagnostics(d, "Unable to create OU", err)
+	assert.Len(t, diags, 4)
+	assert.Contains(t, diags[0].Detail, "validation failed")
+	assert.Equal(t, cty.GetAttrPath("permission_scheme_id"), diags[1].AttributePath)
+	assert.Equal(t, "permission_scheme_id: scheme 99 not found", diags[1].Detail)
+	assert.Equal(t, cty.GetAttrPath("owner_users"), diags[2].AttributePath)
+	assert.Nil(t, diags[3].AttributePath)
+}
+
+func TestReadImportedState(t *testing.T) {
+	r := resourceProjectFunding()
+	newData := func() *schema.ResourceData {
+		d := r.TestResourceData()
+		d.SetId("3")
+		return d
+	}
+
+	found := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics { return nil }
+	imported, err := readImportedState(context.Background(), newData(), nil, found)
+	assert.NoError(t, err)
+	assert.Len(t, imported, 1)
+
+	notFound := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d.SetId("")
+		return nil
+	}
+	_, err = readImportedState(context.Background(), newData(), nil, notFound)
+	assert.ErrorContains(t, err, `unable to import "3"`)
+
+	failed := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return diag.Errorf("Unable to read Project funding")
+	}
+	_, err = readImportedState(context.Background(), newData(), nil, failed)
+	assert.EqualError(t, err, "Unable to read Project funding")
+}
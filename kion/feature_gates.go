@@ -0,0 +1,96 @@
+package kion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+// requireKionVersion fails the plan when resourceType is used with a Kion
+// installation older than min.
+func requireKionVersion(resourceType string, min hc.Version) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		client, ok := m.(*hc.Client)
+		if !ok {
+			return nil
+		}
+		return client.RequireVersion(min, resourceType+" resources")
+	}
+}
+
+// requireKionVersionForAttribute fails the plan when attribute is set on
+// resourceType and the Kion installation is older than min.
+func requireKionVersionForAttribute(resourceType, attribute string, min hc.Version) schema.CustomizeDiffFunc {
+	return customdiff.If(
+		func(ctx context.Context, d *schema.ResourceDiff, m interface{}) bool {
+			_, ok := d.GetOk(attribute)
+			return ok
+		},
+		func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			client, ok := m.(*hc.Client)
+			if !ok {
+				return nil
+			}
+			return client.RequireVersion(min, fmt.Sprintf("%s on %s", attribute, resourceType))
+		},
+	)
+}
+
+// validateProjectFundingMode fails the plan of a new project when the
+// configured funding does not match the budget mode of Kion. Kion only
+// accepts budgets or project funding with a new project in the matching mode.
+func validateProjectFundingMode(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := m.(*hc.Client)
+	if !ok || d.Id() != "" {
+		return nil
+	}
+
+	_, hasBudget := d.GetOk("budget")
+	_, hasFunding := d.GetOk("project_funding")
+	if !hasBudget && !hasFunding {
+		return nil
+	}
+
+	budgetMode, err := client.BudgetMode(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve financial config: %w", err)
+	}
+
+	if hasBudget && !budgetMode {
+		return fmt.Errorf("budget on kion_project requires budget mode to be enabled in the Kion financial settings, use project_funding instead")
+	}
+	if hasFunding && budgetMode {
+		return fmt.Errorf("project_funding on kion_project is not supported when budget mode is enabled in the Kion financial settings, use budget instead")
+	}
+
+	return nil
+}
+
+// requireBudgetMode fails the plan of a new resourceType when the budget mode
+// of Kion does not match budgetMode. Budgets replace project funding when
+// budget mode is enabled in the Kion financial settings.
+func requireBudgetMode(resourceType string, budgetMode bool) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		client, ok := m.(*hc.Client)
+		if !ok || d.Id() != "" {
+			return nil
+		}
+
+		enabled, err := client.BudgetMode(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve financial config: %w", err)
+		}
+
+		if budgetMode && !enabled {
+			return fmt.Errorf("%s requires budget mode to be enabled in the Kion financial settings", resourceType)
+		}
+		if !budgetMode && enabled {
+			return fmt.Errorf("%s is not supported when budget mode is enabled in the Kion financial settings", resourceType)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,101 @@
+package kionclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// credentials are the username and password of a user of an identity
+// management system (IDMS) in Kion.
+type credentials struct {
+	idmsID   int
+	username string
+	password string
+}
+
+// SetCredentials makes the client authenticate with a username and password
+// instead of an API key. Call Login to obtain the first session token; the
+// client logs in again when Kion rejects an expired token.
+func (client *Client) SetCredentials(idmsID int, username, password string) {
+	client.authMu.Lock()
+	defer client.authMu.Unlock()
+	client.credentials = &credentials{idmsID: idmsID, username: username, password: password}
+}
+
+// Login exchanges the credentials set with SetCredentials for a session
+// token.
+func (client *Client) Login(ctx context.Context) error {
+	client.authMu.Lock()
+	defer client.authMu.Unlock()
+	return client.login(ctx)
+}
+
+// login must be called with authMu held.
+func (client *Client) login(ctx context.Context) error {
+	if client.credentials == nil {
+		return errors.New("no credentials to log in to Kion with")
+	}
+
+	req, err := client.newRequest(ctx, http.MethodPost, "/v3/login", LoginRequest{
+		IDMSID:   client.credentials.idmsID,
+		Username: client.credentials.username,
+		Password: client.credentials.password,
+	})
+	if err != nil {
+		return err
+	}
+	client.setHeaders(req)
+
+	body, _, err := client.sendWithRetries(req)
+	if err != nil {
+		return fmt.Errorf("unable to log in to Kion as %s: %w", client.credentials.username, err)
+	}
+
+	var resp LoginResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("could not unmarshal response body: %v", string(body))
+	}
+	if resp.Data.Access.Token == "" {
+		return fmt.Errorf("unable to log in to Kion as %s: no session token returned", client.credentials.username)
+	}
+
+	client.Token = resp.Data.Access.Token
+	tflog.Debug(ctx, "Logged in to Kion", map[string]interface{}{
+		"username": client.credentials.username,
+		"expiry":   resp.Data.Access.Expiry,
+	})
+	return nil
+}
+
+// token returns the token sent as the bearer token of requests.
+func (client *Client) token() string {
+	client.authMu.Lock()
+	defer client.authMu.Unlock()
+	return client.Token
+}
+
+// reauthenticate logs in again after Kion rejected staleToken. It reports
+// whether a new token is available; requests that fail concurrently with
+// the same token share a single login.
+func (client *Client) reauthenticate(ctx context.Context, staleToken string) (bool, error) {
+	client.authMu.Lock()
+	defer client.authMu.Unlock()
+
+	if client.credentials == nil {
+		return false, nil
+	}
+	if client.Token != staleToken {
+		return true, nil
+	}
+
+	tflog.Info(ctx, "Kion rejected the session token, logging in again")
+	if err := client.login(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,162 @@
+package kionclient
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// responseCache keeps the bodies of list requests for the lifetime of the
+// client, i.e. a single Terraform run. Concurrent requests for the same URL
+// share one request to Kion.
+type responseCache struct {
+	mu          sync.Mutex
+	entries     map[string]map[string][]byte
+	calls       map[string]*cacheCall
+	generations map[string]uint64
+}
+
+// cacheCall is a request in flight that other callers wait for.
+type cacheCall struct {
+	done       chan struct{}
+	body       []byte
+	statusCode int
+	err        error
+	// canceled is set when the request failed because its context ended.
+	canceled bool
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{
+		entries:     make(map[string]map[string][]byte),
+		calls:       make(map[string]*cacheCall),
+		generations: make(map[string]uint64),
+	}
+}
+
+// relatedCollections lists collections whose lists change together, e.g.
+// converting a cached account into an account.
+var relatedCollections = map[string][]string{
+	"account":       {"account-cache"},
+	"account-cache": {"account"},
+}
+
+// SetCacheEnabled turns the cache for list requests on or off. When enabled,
+// GET requests for a collection such as /v3/ou are answered from memory
+// until a POST, PUT, PATCH or DELETE request changes that collection.
+func (client *Client) SetCacheEnabled(enabled bool) {
+	if !enabled {
+		client.cache = nil
+		return
+	}
+	client.cache = newResponseCache()
+}
+
+// collection returns the collection a request path belongs to, e.g. project
+// for /api/v3/project/5/owner, and whether the path is the list endpoint of
+// that collection. The API version is not part of the collection, so changes
+// through the v1 and v2 endpoints also invalidate the v3 lists.
+func collection(hostURL, rawURL string) (string, bool) {
+	p := strings.TrimPrefix(rawURL, hostURL)
+	if i := strings.IndexAny(p, "?#"); i >= 0 {
+		p = p[:i]
+	}
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	if !isAPIVersion(segments[0]) {
+		return segments[0], len(segments) == 1
+	}
+	if len(segments) < 2 {
+		return "", false
+	}
+	return segments[1], len(segments) == 2
+}
+
+// isAPIVersion reports whether a path segment is an API version such as v3.
+func isAPIVersion(segment string) bool {
+	if len(segment) < 2 || segment[0] != 'v' {
+		return false
+	}
+	for _, r := range segment[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// doCachedRequest sends a GET request for a list endpoint through the cache,
+// other requests are sent as is. Mutating requests invalidate the cached
+// responses of their collection.
+func (client *Client) doCachedRequest(req *http.Request) ([]byte, int, error) {
+	cache := client.cache
+	if cache == nil {
+		return client.doRequest(req)
+	}
+
+	key := req.URL.String()
+	coll, isList := collection(client.HostURL, key)
+
+	if req.Method != http.MethodGet {
+		body, statusCode, err := client.doRequest(req)
+		cache.invalidate(coll)
+		return body, statusCode, err
+	}
+	if !isList {
+		return client.doRequest(req)
+	}
+
+	for {
+		cache.mu.Lock()
+		if body, ok := cache.entries[coll][key]; ok {
+			cache.mu.Unlock()
+			return body, http.StatusOK, nil
+		}
+		if call, ok := cache.calls[key]; ok {
+			cache.mu.Unlock()
+			select {
+			case <-call.done:
+			case <-req.Context().Done():
+				return nil, 0, NewRequestError(0, req.Context().Err())
+			}
+			// The context of the request that was sent is not the context of
+			// this caller, send the request again rather than share its end.
+			if call.canceled {
+				continue
+			}
+			return call.body, call.statusCode, call.err
+		}
+		call := &cacheCall{done: make(chan struct{})}
+		cache.calls[key] = call
+		generation := cache.generations[coll]
+		cache.mu.Unlock()
+
+		call.body, call.statusCode, call.err = client.doRequest(req)
+		call.canceled = call.err != nil && req.Context().Err() != nil
+
+		cache.mu.Lock()
+		delete(cache.calls, key)
+		// Don't keep a response that may predate a change to the collection.
+		if call.err == nil && cache.generations[coll] == generation {
+			if cache.entries[coll] == nil {
+				cache.entries[coll] = make(map[string][]byte)
+			}
+			cache.entries[coll][key] = call.body
+		}
+		cache.mu.Unlock()
+		close(call.done)
+
+		return call.body, call.statusCode, call.err
+	}
+}
+
+// invalidate drops the cached responses of a collection and the
+// collections related to it.
+func (c *responseCache) invalidate(coll string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, name := range append([]string{coll}, relatedCollections[coll]...) {
+		c.generations[name]++
+		delete(c.entries, name)
+	}
+}
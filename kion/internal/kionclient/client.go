@@ -3,17 +3,21 @@ package kionclient
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"path"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type RequestError struct {
@@ -25,62 +29,281 @@ func (r RequestError) Error() string {
 	return r.Err.Error()
 }
 
+// Unwrap returns the underlying error so errors.Is and errors.As can inspect
+// it, e.g. to detect a cancelled context.
+func (r RequestError) Unwrap() error {
+	return r.Err
+}
+
 func NewRequestError(statusCode int, err error) error {
 	return &RequestError{StatusCode: statusCode, Err: err}
 }
 
+// IsNotFound reports whether Kion responded with 404 Not Found.
+func (r RequestError) IsNotFound() bool {
+	return r.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether Kion responded with 401 Unauthorized.
+func (r RequestError) IsUnauthorized() bool {
+	return r.StatusCode == http.StatusUnauthorized
+}
+
+// IsConflict reports whether Kion responded with 409 Conflict.
+func (r RequestError) IsConflict() bool {
+	return r.StatusCode == http.StatusConflict
+}
+
+// IsForbidden reports whether Kion responded with 403 Forbidden.
+func (r RequestError) IsForbidden() bool {
+	return r.StatusCode == http.StatusForbidden
+}
+
+// IsNotFound reports whether err, or any error it wraps, is a RequestError
+// for a 404 Not Found response.
+func IsNotFound(err error) bool {
+	var reqErr *RequestError
+	return errors.As(err, &reqErr) && reqErr.IsNotFound()
+}
+
+// IsUnauthorized reports whether err, or any error it wraps, is a RequestError
+// for a 401 Unauthorized response.
+func IsUnauthorized(err error) bool {
+	var reqErr *RequestError
+	return errors.As(err, &reqErr) && reqErr.IsUnauthorized()
+}
+
+// IsConflict reports whether err, or any error it wraps, is a RequestError
+// for a 409 Conflict response.
+func IsConflict(err error) bool {
+	var reqErr *RequestError
+	return errors.As(err, &reqErr) && reqErr.IsConflict()
+}
+
+// IsForbidden reports whether err, or any error it wraps, is a RequestError
+// for a 403 Forbidden response.
+func IsForbidden(err error) bool {
+	var reqErr *RequestError
+	return errors.As(err, &reqErr) && reqErr.IsForbidden()
+}
+
 // Client represents a client to interact with the Kion application.
 type Client struct {
 	HostURL    string
 	HTTPClient *http.Client
 	Token      string
+
+	// UserAgent is sent as the User-Agent header when not empty.
+	UserAgent string
+	// ExtraHeaders are added to every request, e.g. headers required by a
+	// web application firewall in front of Kion.
+	ExtraHeaders map[string]string
+
+	// MaxRetries is the number of times a request that failed with a
+	// transient error is retried before giving up.
+	MaxRetries int
+	// RetryMaxWait is the longest the client waits between two attempts.
+	RetryMaxWait time.Duration
+
+	// limiter and inFlight throttle requests, see SetRateLimit and
+	// SetMaxConcurrentRequests.
+	limiter  *rateLimiter
+	inFlight chan struct{}
+
+	// cache holds the responses of list requests, see SetCacheEnabled.
+	cache *responseCache
+
+	// KionVersion is the version of the Kion installation, nil if it could
+	// not be detected. See DetectVersion.
+	KionVersion *Version
+
+	// authMu guards Token and credentials, see SetCredentials.
+	authMu      sync.Mutex
+	credentials *credentials
+
+	// ValidateReferences enables the plan time checks that referenced
+	// objects exist, see ReferenceExists.
+	ValidateReferences bool
+	referencesOnce     sync.Once
+	references         *references
+
+	financialConfigMu sync.Mutex
+	financialConfig   *FinancialConfigResponse
 }
 
-// NewClient creates a new Client instance.
-func NewClient(kionURL, kionAPIKey, kionAPIPath string, skipSSLValidation bool) *Client {
+// NewClient creates a new Client instance. An error is returned if kionURL
+// is not an absolute http or https URL.
+func NewClient(kionURL, kionAPIKey, kionAPIPath string, skipSSLValidation bool) (*Client, error) {
+	u, err := url.Parse(kionURL)
+	if err != nil {
+		return nil, fmt.Errorf("the URL is not valid: %s, %w", kionURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("the URL is not valid: %s, expected an absolute http or https URL such as https://kion.example.com", kionURL)
+	}
+	u.Path = path.Join(strings.TrimRight(u.Path, "/"), strings.TrimRight(kionAPIPath, "/"))
+
 	customTransport := http.DefaultTransport.(*http.Transport).Clone()
 	customTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: skipSSLValidation}
 
 	client := &Client{
+		HostURL: u.String(),
 		HTTPClient: &http.Client{
 			Transport: customTransport,
 		},
-		Token: kionAPIKey,
+		Token:        kionAPIKey,
+		MaxRetries:   DefaultMaxRetries,
+		RetryMaxWait: DefaultRetryMaxWait,
 	}
 
-	u, err := url.Parse(kionURL)
-	if err != nil {
-		log.Fatalf("The URL is not valid: %s, %v", kionURL, err)
-	}
-	u.Path = path.Join(strings.TrimRight(u.Path, "/"), strings.TrimRight(kionAPIPath, "/"))
-	client.HostURL = u.String()
+	return client, nil
+}
 
-	return client
+// setHeaders adds the configured User-Agent and extra headers to req.
+func (client *Client) setHeaders(req *http.Request) {
+	for name, value := range client.ExtraHeaders {
+		req.Header.Set(name, value)
+	}
+	if client.UserAgent != "" {
+		req.Header.Set("User-Agent", client.UserAgent)
+	}
 }
 
 func (client *Client) doRequest(req *http.Request) ([]byte, int, error) {
-	req.Header.Set("Authorization", "Bearer "+client.Token)
+	ctx := req.Context()
+	client.setHeaders(req)
+	token := client.token()
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, statusCode, err := client.sendWithRetries(req)
+	if statusCode != http.StatusUnauthorized {
+		return body, statusCode, err
+	}
+
+	// The session token may have expired, log in again and retry once.
+	ok, authErr := client.reauthenticate(ctx, token)
+	if authErr != nil {
+		return nil, statusCode, NewRequestError(statusCode, authErr)
+	}
+	if !ok {
+		return body, statusCode, err
+	}
+	if req.GetBody != nil {
+		if req.Body, err = req.GetBody(); err != nil {
+			return nil, statusCode, NewRequestError(statusCode, err)
+		}
+	}
+	req.Header.Set("Authorization", "Bearer "+client.token())
+	return client.sendWithRetries(req)
+}
+
+// sendWithRetries sends a request, retrying transient errors.
+func (client *Client) sendWithRetries(req *http.Request) ([]byte, int, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		body, statusCode, res, err := client.send(req)
+		tflog.Debug(ctx, "Kion API request", map[string]interface{}{
+			"method":      req.Method,
+			"url":         req.URL.String(),
+			"status_code": statusCode,
+			"attempt":     attempt + 1,
+			"duration_ms": time.Since(start).Milliseconds(),
+		})
+
+		// Don't retry when Terraform cancelled the operation or its timeout
+		// expired.
+		if ctx.Err() != nil {
+			return body, statusCode, err
+		}
+		if attempt >= client.MaxRetries || !shouldRetry(req.Method, statusCode, transportError(err)) {
+			return body, statusCode, err
+		}
+
+		wait := client.retryWait(attempt, res)
+		tflog.Warn(ctx, "Retrying Kion API request after transient error", map[string]interface{}{
+			"method": req.Method,
+			"url":    req.URL.String(),
+			"error":  err.Error(),
+			"wait":   wait.String(),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, statusCode, NewRequestError(statusCode, ctx.Err())
+		case <-timer.C:
+		}
+
+		// Rewind the request body so it can be sent again.
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, statusCode, NewRequestError(statusCode, err)
+			}
+		}
+	}
+}
+
+// send performs a single attempt of a request. The returned response is
+// only used to inspect headers, its body is already consumed.
+func (client *Client) send(req *http.Request) ([]byte, int, *http.Response, error) {
+	release, err := client.acquire(req.Context())
+	defer release()
+	if err != nil {
+		return nil, 0, nil, NewRequestError(0, err)
+	}
 
 	res, err := client.HTTPClient.Do(req)
 	if err != nil {
-		return nil, 0, NewRequestError(0, err)
+		return nil, 0, nil, NewRequestError(0, err)
 	}
 	defer res.Body.Close()
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, res.StatusCode, NewRequestError(res.StatusCode, err)
+		return nil, res.StatusCode, res, NewRequestError(res.StatusCode, err)
 	}
 
 	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
-		return nil, res.StatusCode, NewRequestError(res.StatusCode, fmt.Errorf("url: %s, method: %s, status: %d, body: %s", req.URL.String(), req.Method, res.StatusCode, body))
+		return nil, res.StatusCode, res, NewRequestError(res.StatusCode, parseAPIError(res.StatusCode, req.Method, req.URL.String(), body))
+	}
+
+	return body, res.StatusCode, res, nil
+}
+
+// transportError returns the underlying error if the request failed before a
+// response was received from Kion.
+func transportError(err error) error {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.StatusCode == 0 {
+		return reqErr.Err
+	}
+	return nil
+}
+
+// newRequest builds a request bound to ctx with an optional JSON body.
+func (client *Client) newRequest(ctx context.Context, method, urlPath string, sendData interface{}) (*http.Request, error) {
+	var body io.Reader
+	if sendData != nil {
+		rb, err := json.Marshal(sendData)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(rb)
 	}
 
-	return body, res.StatusCode, nil
+	return http.NewRequestWithContext(ctx, method, client.HostURL+urlPath, body)
 }
 
 // GET retrieves an element from Kion.
 func (client *Client) GET(urlPath string, returnData interface{}) error {
+	return client.GETContext(context.Background(), urlPath, returnData)
+}
+
+// GETContext retrieves an element from Kion. The request is cancelled when
+// ctx is done.
+func (client *Client) GETContext(ctx context.Context, urlPath string, returnData interface{}) error {
 	if returnData != nil {
 		v := reflect.ValueOf(returnData)
 		if v.Kind() != reflect.Ptr {
@@ -88,12 +311,12 @@ func (client *Client) GET(urlPath string, returnData interface{}) error {
 		}
 	}
 
-	req, err := http.NewRequest(http.MethodGet, client.HostURL+urlPath, nil)
+	req, err := client.newRequest(ctx, http.MethodGet, urlPath, nil)
 	if err != nil {
 		return err
 	}
 
-	body, statusCode, err := client.doRequest(req)
+	body, statusCode, err := client.doCachedRequest(req)
 	if err != nil {
 		return err
 	}
@@ -109,17 +332,24 @@ func (client *Client) GET(urlPath string, returnData interface{}) error {
 
 // POST creates an element in Kion.
 func (client *Client) POST(urlPath string, sendData interface{}) (*Creation, error) {
+	return client.POSTContext(context.Background(), urlPath, sendData)
+}
+
+// POSTContext creates an element in Kion. The request is cancelled when ctx
+// is done.
+func (client *Client) POSTContext(ctx context.Context, urlPath string, sendData interface{}) (*Creation, error) {
+	// POST requests always send a body, even if it is just "null".
 	rb, err := json.Marshal(sendData)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, client.HostURL+urlPath, bytes.NewBuffer(rb))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.HostURL+urlPath, bytes.NewBuffer(rb))
 	if err != nil {
 		return nil, err
 	}
 
-	body, _, err := client.doRequest(req)
+	body, _, err := client.doCachedRequest(req)
 	if err != nil {
 		return nil, err
 	}
@@ -134,57 +364,67 @@ func (client *Client) POST(urlPath string, sendData interface{}) (*Creation, err
 
 // PATCH updates an element in Kion.
 func (client *Client) PATCH(urlPath string, sendData interface{}) error {
-	return client.doPutPatch(http.MethodPatch, urlPath, sendData)
+	return client.PATCHContext(context.Background(), urlPath, sendData)
+}
+
+// PATCHContext updates an element in Kion. The request is cancelled when ctx
+// is done.
+func (client *Client) PATCHContext(ctx context.Context, urlPath string, sendData interface{}) error {
+	return client.doPutPatch(ctx, http.MethodPatch, urlPath, sendData)
 }
 
 // PUT updates an element in Kion.
 func (client *Client) PUT(urlPath string, sendData interface{}) error {
-	return client.doPutPatch(http.MethodPut, urlPath, sendData)
+	return client.PUTContext(context.Background(), urlPath, sendData)
+}
+
+// PUTContext updates an element in Kion. The request is cancelled when ctx
+// is done.
+func (client *Client) PUTContext(ctx context.Context, urlPath string, sendData interface{}) error {
+	return client.doPutPatch(ctx, http.MethodPut, urlPath, sendData)
 }
 
 // doPutPatch is a helper for PUT and PATCH methods.
-func (client *Client) doPutPatch(method, urlPath string, sendData interface{}) error {
+func (client *Client) doPutPatch(ctx context.Context, method, urlPath string, sendData interface{}) error {
 	rb, err := json.Marshal(sendData)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest(method, client.HostURL+urlPath, bytes.NewBuffer(rb))
+	req, err := http.NewRequestWithContext(ctx, method, client.HostURL+urlPath, bytes.NewBuffer(rb))
 	if err != nil {
 		return err
 	}
 
-	_, _, err = client.doRequest(req)
+	_, _, err = client.doCachedRequest(req)
 	return err
 }
 
 // DELETE removes an element from Kion. sendData can be nil.
 func (client *Client) DELETE(urlPath string, sendData interface{}) error {
-	return client.DeleteWithResponse(urlPath, sendData, nil)
+	return client.DELETEContext(context.Background(), urlPath, sendData)
+}
+
+// DELETEContext removes an element from Kion. sendData can be nil. The
+// request is cancelled when ctx is done.
+func (client *Client) DELETEContext(ctx context.Context, urlPath string, sendData interface{}) error {
+	return client.DeleteWithResponseContext(ctx, urlPath, sendData, nil)
 }
 
 // DeleteWithResponse deletes an element from Kion and returns a response.
 func (client *Client) DeleteWithResponse(urlPath string, sendData, returnData interface{}) error {
-	var req *http.Request
-	var err error
+	return client.DeleteWithResponseContext(context.Background(), urlPath, sendData, returnData)
+}
 
-	if sendData != nil {
-		rb, err := json.Marshal(sendData)
-		if err != nil {
-			return err
-		}
-		req, err = http.NewRequest(http.MethodDelete, client.HostURL+urlPath, bytes.NewBuffer(rb))
-		if err != nil {
-			return err
-		}
-	} else {
-		req, err = http.NewRequest(http.MethodDelete, client.HostURL+urlPath, nil)
-		if err != nil {
-			return err
-		}
+// DeleteWithResponseContext deletes an element from Kion and returns a
+// response. The request is cancelled when ctx is done.
+func (client *Client) DeleteWithResponseContext(ctx context.Context, urlPath string, sendData, returnData interface{}) error {
+	req, err := client.newRequest(ctx, http.MethodDelete, urlPath, sendData)
+	if err != nil {
+		return err
 	}
 
-	body, statusCode, err := client.doRequest(req)
+	body, statusCode, err := client.doCachedRequest(req)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,442 @@
+package kionclient
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client pointed at the given test server that
+// retries without waiting.
+func newTestClient(server *httptest.Server) *Client {
+	client, err := NewClient(server.URL, "app_1_test", "/api", false)
+	if err != nil {
+		panic(err)
+	}
+	client.RetryMaxWait = time.Millisecond
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	client, err := NewClient("https://kion.example.com/", "app_1_test", "/api/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://kion.example.com/api", client.HostURL)
+
+	for _, invalid := range []string{"kion.example.com", "ftp://kion.example.com", "https://", "://kion"} {
+		_, err = NewClient(invalid, "app_1_test", "/api", false)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRetryTransientStatus(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": 200}`))
+	}))
+	defer server.Close()
+
+	err := newTestClient(server).GET("/v3/ou", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	client.MaxRetries = 2
+
+	err := client.GET("/v3/ou", nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPostOnlyWhenNotProcessed(t *testing.T) {
+	var calls int32
+	status := http.StatusBadGateway
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).POST("/v3/ou", map[string]string{"name": "test"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// A 503 may come from a proxy after Kion processed the request.
+	atomic.StoreInt32(&calls, 0)
+	status = http.StatusServiceUnavailable
+	_, err = newTestClient(server).POST("/v3/ou", map[string]string{"name": "test"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"record_id": 5, "status": 201}`))
+	})
+
+	resp, err := newTestClient(server).POST("/v3/ou", map[string]string{"name": "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, resp.RecordID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestContextCancelStopsRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	client.RetryMaxWait = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.GETContext(ctx, "/v3/ou", nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var current, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	client.SetMaxConcurrentRequests(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.GET("/v3/ou", nil))
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(20)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		assert.NoError(t, limiter.Wait(ctx))
+	}
+
+	// The first 20 requests use the burst, the next 10 need half a second.
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
+
+func TestRequestErrorStatusHelpers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	err := newTestClient(server).GET("/v3/project/1", nil)
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsConflict(err))
+	assert.False(t, IsForbidden(err))
+
+	wrapped := fmt.Errorf("reading project: %w", err)
+	assert.True(t, IsNotFound(wrapped))
+
+	assert.True(t, IsConflict(NewRequestError(http.StatusConflict, errors.New("conflict"))))
+	assert.True(t, IsForbidden(NewRequestError(http.StatusForbidden, errors.New("forbidden"))))
+	assert.False(t, IsNotFound(errors.New("not a request error")))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	wait, ok := parseRetryAfter("7", now)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, wait)
+
+	wait, ok = parseRetryAfter(now.Add(10*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Second, wait)
+
+	_, ok = parseRetryAfter("", now)
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
+
+func TestSetTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	client.MaxRetries = 0
+	assert.Error(t, client.GET("/v3/ou", nil))
+
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, client.SetTLSConfig(caCert, nil, nil))
+	assert.NoError(t, client.GET("/v3/ou", nil))
+
+	assert.Error(t, client.SetTLSConfig([]byte("not a certificate"), nil, nil))
+	assert.Error(t, client.SetTLSConfig(nil, caCert, nil))
+	assert.Error(t, client.SetTLSConfig(nil, caCert, []byte("not a key")))
+}
+
+func TestRequestHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer app_1_test", r.Header.Get("Authorization"))
+		assert.Equal(t, "terraform-provider-kion/1.0.0", r.Header.Get("User-Agent"))
+		assert.Equal(t, "secret", r.Header.Get("X-Waf-Token"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	client.UserAgent = "terraform-provider-kion/1.0.0"
+	client.ExtraHeaders = map[string]string{"X-Waf-Token": "secret", "Authorization": "ignored"}
+	assert.NoError(t, client.GET("/v3/ou", nil))
+}
+
+func TestSetProxy(t *testing.T) {
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		assert.Equal(t, "kion.example.invalid", r.URL.Host)
+		assert.Equal(t, "Basic dXNlcjpwYXNz", r.Header.Get("Proxy-Authorization"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient("http://kion.example.invalid", "app_1_test", "/api", false)
+	assert.NoError(t, err)
+	client.MaxRetries = 0
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	proxyURL.User = url.UserPassword("user", "pass")
+	assert.NoError(t, client.SetProxy(proxyURL, ""))
+	assert.NoError(t, client.GET("/v3/ou", nil))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&proxied))
+
+	assert.NoError(t, client.SetProxy(proxyURL, ".example.invalid"))
+	assert.Error(t, client.GET("/v3/ou", nil))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&proxied))
+
+	assert.Error(t, client.SetProxy(&url.URL{Path: "proxy"}, ""))
+}
+
+func TestLoginAndReauthenticate(t *testing.T) {
+	var logins, calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v3/login" {
+			n := atomic.AddInt32(&logins, 1)
+			assert.Empty(t, r.Header.Get("Authorization"))
+			_, _ = fmt.Fprintf(w, `{"status": 200, "data": {"user_id": 1, "access": {"token": "session-%d"}}}`, n)
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		// Only the second session token is valid.
+		if r.Header.Get("Authorization") != "Bearer session-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"record_id": 1, "status": 201}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "", "/api", false)
+	assert.NoError(t, err)
+	client.SetCredentials(1, "admin", "secret")
+	assert.NoError(t, client.Login(context.Background()))
+	assert.Equal(t, "session-1", client.Token)
+
+	_, err = client.POST("/v3/ou", map[string]string{"name": "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&logins))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// Without credentials a rejected token is not refreshed.
+	client = newTestClient(server)
+	err = client.GET("/v3/ou", nil)
+	assert.True(t, IsUnauthorized(err))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&logins))
+}
+
+func TestResponseCache(t *testing.T) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"record_id": 1, "status": 200}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	client.SetCacheEnabled(true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.GET("/v3/ou", nil))
+		}()
+	}
+	wg.Wait()
+	assert.NoError(t, client.GET("/v3/project", nil))
+	assert.NoError(t, client.GET("/v3/ou/1", nil))
+	assert.NoError(t, client.GET("/v3/ou/1", nil))
+	assert.Equal(t, 1, calls["GET /api/v3/ou"])
+	assert.Equal(t, 2, calls["GET /api/v3/ou/1"])
+
+	// Changing an OU invalidates the OU list, but not the project list.
+	assert.NoError(t, client.PATCH("/v3/ou/1", map[string]string{"name": "test"}))
+	assert.NoError(t, client.GET("/v3/ou", nil))
+	assert.NoError(t, client.GET("/v3/project", nil))
+	assert.Equal(t, 2, calls["GET /api/v3/ou"])
+	assert.Equal(t, 1, calls["GET /api/v3/project"])
+
+	// Converting a cached account invalidates the account list.
+	assert.NoError(t, client.GET("/v3/account", nil))
+	_, err := client.POST("/v3/account-cache/1/convert/2", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, client.GET("/v3/account", nil))
+	assert.Equal(t, 2, calls["GET /api/v3/account"])
+
+	// Changes through older API versions invalidate the v3 lists.
+	assert.NoError(t, client.DELETE("/v2/ou/1", nil))
+	assert.NoError(t, client.GET("/v3/ou", nil))
+	assert.Equal(t, 3, calls["GET /api/v3/ou"])
+	_, err = client.POST("/v1/project/1/owner", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, client.GET("/v3/project", nil))
+	assert.Equal(t, 2, calls["GET /api/v3/project"])
+
+	client.SetCacheEnabled(false)
+	assert.NoError(t, client.GET("/v3/project", nil))
+	assert.Equal(t, 3, calls["GET /api/v3/project"])
+}
+
+func TestResponseCacheLeaderCanceled(t *testing.T) {
+	var requests int32
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			close(started)
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": 200}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	client.MaxRetries = 0
+	client.SetCacheEnabled(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() { leader <- client.GETContext(ctx, "/v3/ou", nil) }()
+	<-started
+
+	waiter := make(chan error)
+	go func() { waiter <- client.GET("/v3/ou", nil) }()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	// The waiter sends the request again instead of failing with the
+	// context error of the request it waited for.
+	assert.Error(t, <-leader)
+	assert.NoError(t, <-waiter)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestReferenceExists(t *testing.T) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/api/v3/ou/1":
+			_, _ = w.Write([]byte(`{"data": {"id": 1}, "status": 200}`))
+		case "/api/v3/label":
+			_, _ = w.Write([]byte(`{"data": {"items": [{"id": 1, "key": "env", "value": "prod"}], "total": 1}, "status": 200}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found", "status": 404}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+
+	exists, err := client.ReferenceExists(context.Background(), ReferenceOU, 1)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.ReferenceExists(context.Background(), ReferenceOU, 2)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// Lookups are only sent once.
+	_, _ = client.ReferenceExists(context.Background(), ReferenceOU, 2)
+	assert.Equal(t, 1, calls["/api/v3/ou/2"])
+
+	exists, err = client.LabelKeyExists(context.Background(), "env")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.LabelKeyExists(context.Background(), "team")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, 1, calls["/api/v3/label"])
+}
@@ -0,0 +1,129 @@
+package kionclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// APIError is the decoded error payload of a failed Kion API request.
+type APIError struct {
+	StatusCode  int
+	Method      string
+	URL         string
+	Message     string
+	FieldErrors []FieldError
+}
+
+// FieldError is a validation error Kion reported for a single request field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s returned %d", e.Method, e.URL, e.StatusCode)
+	if e.Message != "" {
+		sb.WriteString(": " + e.Message)
+	}
+	for _, fe := range e.FieldErrors {
+		fmt.Fprintf(&sb, "; %s: %s", fe.Field, fe.Message)
+	}
+	return sb.String()
+}
+
+// AsAPIError returns the APIError wrapped in err, if any.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// errorEnvelope covers the shapes of error bodies returned by the Kion API.
+// Field errors are either a list of objects or a map of field names to one or
+// more messages.
+type errorEnvelope struct {
+	Status  int             `json:"status"`
+	Message string          `json:"message"`
+	Error   string          `json:"error"`
+	Errors  json.RawMessage `json:"errors"`
+}
+
+// parseAPIError builds an APIError from a response body. Bodies that are not
+// a JSON error envelope are kept verbatim as the message.
+func parseAPIError(statusCode int, method, url string, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Method:     method,
+		URL:        url,
+	}
+
+	var env errorEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		apiErr.Message = strings.TrimSpace(string(body))
+		return apiErr
+	}
+
+	apiErr.Message = env.Message
+	if apiErr.Message == "" {
+		apiErr.Message = env.Error
+	}
+	apiErr.FieldErrors = parseFieldErrors(env.Errors)
+
+	if apiErr.Message == "" && len(apiErr.FieldErrors) == 0 {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+
+	return apiErr
+}
+
+func parseFieldErrors(raw json.RawMessage) []FieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var list []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(raw, &list); err == nil {
+		fieldErrors := make([]FieldError, 0, len(list))
+		for _, item := range list {
+			fieldErrors = append(fieldErrors, FieldError{Field: item.Field, Message: item.Message})
+		}
+		return fieldErrors
+	}
+
+	var byField map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &byField); err != nil {
+		return nil
+	}
+
+	fields := make([]string, 0, len(byField))
+	for field := range byField {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	fieldErrors := make([]FieldError, 0, len(byField))
+	for _, field := range fields {
+		var messages []string
+		if err := json.Unmarshal(byField[field], &messages); err != nil {
+			var message string
+			if err := json.Unmarshal(byField[field], &message); err != nil {
+				continue
+			}
+			messages = []string{message}
+		}
+		for _, message := range messages {
+			fieldErrors = append(fieldErrors, FieldError{Field: field, Message: message})
+		}
+	}
+
+	return fieldErrors
+}
@@ -0,0 +1,44 @@
+package kionclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAPIError(t *testing.T) {
+	apiErr := parseAPIError(400, http.MethodPost, "https://kion/api/v3/project", []byte(`{"status": 400, "message": "invalid project"}`))
+	assert.Equal(t, "invalid project", apiErr.Message)
+	assert.Empty(t, apiErr.FieldErrors)
+	assert.Equal(t, "POST https://kion/api/v3/project returned 400: invalid project", apiErr.Error())
+
+	apiErr = parseAPIError(422, http.MethodPost, "/v3/project", []byte(`{"status": 422, "errors": [{"field": "permission_scheme_id", "message": "scheme 99 not found"}]}`))
+	assert.Empty(t, apiErr.Message)
+	assert.Equal(t, []FieldError{{Field: "permission_scheme_id", Message: "scheme 99 not found"}}, apiErr.FieldErrors)
+
+	apiErr = parseAPIError(422, http.MethodPost, "/v3/project", []byte(`{"message": "validation failed", "errors": {"ou_id": ["is required"], "name": "is too long"}}`))
+	assert.Equal(t, "validation failed", apiErr.Message)
+	assert.Equal(t, []FieldError{
+		{Field: "name", Message: "is too long"},
+		{Field: "ou_id", Message: "is required"},
+	}, apiErr.FieldErrors)
+
+	apiErr = parseAPIError(502, http.MethodGet, "/v3/ou", []byte("<html>Bad Gateway</html>\n"))
+	assert.Equal(t, "<html>Bad Gateway</html>", apiErr.Message)
+}
+
+func TestRequestErrorWrapsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status": 400, "message": "Rule is already in progress"}`))
+	}))
+	defer server.Close()
+
+	err := newTestClient(server).GET("/v3/ou", nil)
+	apiErr, ok := AsAPIError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Contains(t, err.Error(), "Rule is already in progress")
+}
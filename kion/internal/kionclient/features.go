@@ -0,0 +1,105 @@
+package kionclient
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Version is the semantic version of a Kion installation.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// Kion versions that introduced features the provider depends on.
+var (
+	// VersionCFTTags added stack-level tags on CloudFormation templates.
+	VersionCFTTags = Version{3, 7, 1}
+	// VersionLabels added PUT /v3/{resource}/{id}/labels.
+	VersionLabels = Version{3, 7, 7}
+	// VersionAccounts added the account and account-cache endpoints used by
+	// the kion_*_account resources.
+	VersionAccounts = Version{3, 8, 4}
+)
+
+var versionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// ParseVersion parses versions such as "3.9.5", "v3.9" or "3.10.0-rc1".
+// Anything after the patch number is ignored.
+func ParseVersion(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("invalid Kion version: %q", s)
+	}
+
+	var v Version
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		v.Patch, _ = strconv.Atoi(m[3])
+	}
+
+	return v, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// LessThan reports whether v is older than other.
+func (v Version) LessThan(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+// DetectVersion queries the version of the Kion installation and stores it in
+// KionVersion so resources can check for supported features.
+func (client *Client) DetectVersion(ctx context.Context) (Version, error) {
+	resp := new(VersionResponse)
+	if err := client.GETContext(ctx, "/version", resp); err != nil {
+		return Version{}, err
+	}
+
+	v, err := ParseVersion(resp.Data)
+	if err != nil {
+		return Version{}, err
+	}
+
+	client.KionVersion = &v
+	return v, nil
+}
+
+// RequireVersion returns an error naming feature if the Kion installation is
+// older than min. It passes when the version could not be detected so an
+// unknown version never blocks a plan.
+func (client *Client) RequireVersion(min Version, feature string) error {
+	if client.KionVersion == nil || !client.KionVersion.LessThan(min) {
+		return nil
+	}
+	return fmt.Errorf("%s require Kion >= %s, but %s is running %s", feature, min, client.HostURL, client.KionVersion)
+}
+
+// BudgetMode reports whether budgets instead of project funding are enabled in
+// the financial settings of Kion. The setting is fetched once per client.
+func (client *Client) BudgetMode(ctx context.Context) (bool, error) {
+	client.financialConfigMu.Lock()
+	defer client.financialConfigMu.Unlock()
+
+	if client.financialConfig == nil {
+		config := new(FinancialConfigResponse)
+		if err := client.GETContext(ctx, "/v1/ct-config/financials-config", config); err != nil {
+			return false, err
+		}
+		client.financialConfig = config
+	}
+
+	return client.financialConfig.Data.BudgetMode, nil
+}
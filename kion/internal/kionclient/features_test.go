@@ -0,0 +1,45 @@
+package kionclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	for input, expected := range map[string]Version{
+		"3.9.5":      {3, 9, 5},
+		"v3.7.7":     {3, 7, 7},
+		"3.10":       {3, 10, 0},
+		"3.10.0-rc1": {3, 10, 0},
+	} {
+		v, err := ParseVersion(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, v, input)
+	}
+
+	_, err := ParseVersion("unknown")
+	assert.Error(t, err)
+}
+
+func TestVersionLessThan(t *testing.T) {
+	assert.True(t, Version{3, 7, 6}.LessThan(VersionLabels))
+	assert.True(t, Version{3, 6, 9}.LessThan(VersionLabels))
+	assert.False(t, Version{3, 7, 7}.LessThan(VersionLabels))
+	assert.False(t, Version{3, 10, 0}.LessThan(VersionLabels))
+	assert.False(t, Version{4, 0, 0}.LessThan(VersionLabels))
+}
+
+func TestRequireVersion(t *testing.T) {
+	client := &Client{HostURL: "https://kion.example.com/api"}
+
+	// An unknown version never blocks.
+	assert.NoError(t, client.RequireVersion(VersionLabels, "labels on kion_project"))
+
+	client.KionVersion = &Version{3, 6, 2}
+	err := client.RequireVersion(VersionLabels, "labels on kion_project")
+	assert.EqualError(t, err, "labels on kion_project require Kion >= 3.7.7, but https://kion.example.com/api is running 3.6.2")
+
+	client.KionVersion = &Version{3, 9, 0}
+	assert.NoError(t, client.RequireVersion(VersionLabels, "labels on kion_project"))
+}
@@ -3,7 +3,9 @@ package kionclient
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -29,14 +31,18 @@ func NewFilterable(d *schema.ResourceData) *Filterable {
 		fi := v.(map[string]interface{})
 
 		filterName := fi["name"].(string)
-		filterValues := fi["values"].([]interface{})
+		filterValues, _ := fi["values"].([]interface{})
 		filterRegex := fi["regex"].(bool)
+		filterOperator, _ := fi["operator"].(string)
+		filterArrayMatch, _ := fi["array_match"].(string)
 
 		f := Filter{
-			key:    filterName,
-			keys:   strings.Split(filterName, "."),
-			values: filterValues,
-			regex:  filterRegex,
+			key:        filterName,
+			keys:       strings.Split(filterName, "."),
+			values:     filterValues,
+			regex:      filterRegex,
+			operator:   filterOperator,
+			arrayMatch: filterArrayMatch,
 		}
 
 		arr = append(arr, f)
@@ -56,17 +62,10 @@ func (f *Filterable) Match(m map[string]interface{}) (bool, error) {
 
 	// Loop through each filter.
 	for _, filter := range f.arr {
-		found := false
-		for _, filterValue := range filter.values {
-			match, err := filter.DeepMatch(filter.keys, m, filterValue)
-			if err != nil {
-				return false, err
-			} else if match {
-				found = true
-				break
-			}
-		}
-		if !found {
+		found, err := filter.matchValues(m)
+		if err != nil {
+			return false, err
+		} else if !found {
 			return false, nil
 		}
 	}
@@ -74,54 +73,254 @@ func (f *Filterable) Match(m map[string]interface{}) (bool, error) {
 	return true, nil
 }
 
+// Partition splits the filters into those on the given top level field, e.g.
+// labels, and all others. Either may be nil, which matches everything.
+func (f *Filterable) Partition(field string) (*Filterable, *Filterable) {
+	if f == nil {
+		return nil, nil
+	}
+
+	var on, others []Filter
+	for _, filter := range f.arr {
+		if filter.keys[0] == field {
+			on = append(on, filter)
+		} else {
+			others = append(others, filter)
+		}
+	}
+
+	return newFilterable(on), newFilterable(others)
+}
+
+func newFilterable(arr []Filter) *Filterable {
+	if len(arr) == 0 {
+		return nil
+	}
+	return &Filterable{arr: arr}
+}
+
+// Filter operators. FilterEquals is the default.
+const (
+	FilterEquals    = "equals"
+	FilterNotEquals = "not_equals"
+	FilterContains  = "contains"
+	FilterPrefix    = "prefix"
+	FilterGreater   = "gt"
+	FilterLess      = "lt"
+	FilterExists    = "exists"
+	FilterEmpty     = "empty"
+)
+
+// FilterOperators lists the operators a filter supports.
+var FilterOperators = []string{
+	FilterEquals, FilterNotEquals, FilterContains, FilterPrefix,
+	FilterGreater, FilterLess, FilterExists, FilterEmpty,
+}
+
+// How a filter on a field inside an array, e.g. owner_users.id, matches.
+// FilterMatchAny is the default.
+const (
+	FilterMatchAny = "any"
+	FilterMatchAll = "all"
+)
+
 // Filter -
 type Filter struct {
 	key  string
 	keys []string
 	// These will always be an array of strings so when doing a comparison,
 	// you have to convert to a string using: fmt.Sprint().
-	values []interface{}
-	regex  bool
+	values     []interface{}
+	regex      bool
+	operator   string
+	arrayMatch string
+}
+
+func (f *Filter) op() string {
+	if f.operator == "" {
+		return FilterEquals
+	}
+	return f.operator
+}
+
+// matchValues reports whether m matches the filter. With not_equals m must
+// differ from every value, with the other operators it must match any of
+// them. exists and empty take no values.
+func (f *Filter) matchValues(m map[string]interface{}) (bool, error) {
+	op := f.op()
+	if f.regex && op != FilterEquals && op != FilterNotEquals {
+		return false, fmt.Errorf("filter '%v' can only use regex with the %v or %v operator", f.key, FilterEquals, FilterNotEquals)
+	}
+
+	switch op {
+	case FilterExists, FilterEmpty:
+		return f.DeepMatch(f.keys, m, nil)
+	}
+
+	if len(f.values) == 0 {
+		return false, fmt.Errorf("filter '%v' requires at least one value for the %v operator", f.key, op)
+	}
+
+	for _, filterValue := range f.values {
+		match, err := f.DeepMatch(f.keys, m, filterValue)
+		if err != nil {
+			return false, err
+		}
+		if op == FilterNotEquals && !match {
+			return false, nil
+		}
+		if op != FilterNotEquals && match {
+			return true, nil
+		}
+	}
+
+	return op == FilterNotEquals, nil
 }
 
 // DeepMatch -
 func (f *Filter) DeepMatch(keys []string, m map[string]interface{}, filterValue interface{}) (bool, error) {
+	op := f.op()
+
 	val, ok := m[keys[0]]
 	if !ok {
+		switch op {
+		case FilterExists:
+			return false, nil
+		case FilterEmpty:
+			return true, nil
+		}
 		return false, errors.New("filter is not found: " + keys[0] + fmt.Sprintf(" | %#v", m))
 	}
 
 	if len(keys) == 1 {
+		switch op {
+		case FilterExists:
+			return !isNil(val), nil
+		case FilterEmpty:
+			return isEmpty(val), nil
+		}
 		// Catch a user error if the filter is comparing against an array
 		// ex. Using a filter of 'owner_users' instead of 'owner_users.id'
 		if _, ok := val.([]interface{}); ok {
 			return false, fmt.Errorf("filter key (%v) references an array instead of a field: %v", f.key, fmt.Sprint(val))
 		}
-		// If set as a regex, then compare against it.
-		if f.regex {
-			re, err := regexp.Compile(fmt.Sprint(filterValue))
-			if err != nil {
-				return false, fmt.Errorf("invalid regular expression '%v' for '%v' filter", filterValue, f.key)
-			}
-			return re.MatchString(fmt.Sprint(val)), nil
-		}
-		// filterValue will always be a string so compare accordingly.
-		return fmt.Sprint(val) == filterValue, nil
+		return f.compare(val, filterValue)
 	}
 
 	if x, ok := val.([]interface{}); ok {
-		// If the field is an array, then determine if one of the values matches.
+		// If the field is an array, then determine if one of the values, or
+		// all of them, match. With not_equals no value may equal the filter
+		// value, so all of them must match regardless of array_match.
+		all := f.arrayMatch == FilterMatchAll || op == FilterNotEquals
 		for _, i := range x {
 			vmap := i.(map[string]interface{})
 
 			match, err := f.DeepMatch(keys[1:], vmap, filterValue)
 			if err != nil {
 				return false, err
-			} else if match {
+			} else if match && !all {
 				return true, nil
+			} else if !match && all {
+				return false, nil
 			}
 		}
+		if op == FilterNotEquals {
+			return true, nil
+		}
+		return all && len(x) > 0, nil
+	}
+
+	// Maps such as labels are keyed by arbitrary names, a missing key is
+	// not an error. It differs from every value, so it matches not_equals.
+	if vmap, ok := val.(map[string]interface{}); ok {
+		if _, ok := vmap[keys[1]]; !ok {
+			return op == FilterEmpty || op == FilterNotEquals, nil
+		}
+		return f.DeepMatch(keys[1:], vmap, filterValue)
 	}
 
 	return false, nil
 }
+
+// compare applies the operator of the filter to a field value.
+func (f *Filter) compare(val interface{}, filterValue interface{}) (bool, error) {
+	if isNil(val) {
+		val = ""
+	} else if rv := reflect.ValueOf(val); rv.Kind() == reflect.Ptr {
+		val = rv.Elem().Interface()
+	}
+	actual := fmt.Sprint(val)
+	expected := fmt.Sprint(filterValue)
+
+	switch f.op() {
+	case FilterEquals, FilterNotEquals:
+		// filterValue will always be a string so compare accordingly.
+		equal := actual == expected
+		// If set as a regex, then compare against it.
+		if f.regex {
+			re, err := regexp.Compile(expected)
+			if err != nil {
+				return false, fmt.Errorf("invalid regular expression '%v' for '%v' filter", filterValue, f.key)
+			}
+			equal = re.MatchString(actual)
+		}
+		return equal == (f.op() == FilterEquals), nil
+	case FilterContains:
+		return strings.Contains(actual, expected), nil
+	case FilterPrefix:
+		return strings.HasPrefix(actual, expected), nil
+	case FilterGreater, FilterLess:
+		cmp := compareValues(actual, expected)
+		if f.op() == FilterGreater {
+			return cmp > 0, nil
+		}
+		return cmp < 0, nil
+	}
+
+	return false, fmt.Errorf("unsupported operator '%v' for '%v' filter", f.operator, f.key)
+}
+
+// compareValues compares two values numerically if both are numbers, such
+// as IDs and datecodes, and as strings otherwise.
+func compareValues(a, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// isNil reports whether v is nil or a nil pointer.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// isEmpty reports whether v is nil, an empty string or an empty collection.
+func isEmpty(v interface{}) bool {
+	if isNil(v) {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0
+	case reflect.Ptr:
+		return isEmpty(rv.Elem().Interface())
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package kionclient
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -272,3 +273,125 @@ func TestExtractValue(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.False(t, v)
 }
+
+func TestMatchOperators(t *testing.T) {
+	policyID := 12
+	data := map[string]interface{}{
+		"id":              200,
+		"name":            "prod-network",
+		"start_datecode":  "202401",
+		"description":     "",
+		"azure_policy_id": &policyID,
+		"payer_id":        (*int)(nil),
+		"owner_users":     inflateIntArray([]int{300, 100}),
+		"owner_groups":    inflateIntArray([]int{}),
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		operator   string
+		arrayMatch string
+		regex      bool
+		values     []interface{}
+		want       bool
+	}{
+		{"equals is the default", "name", "", "", false, []interface{}{"prod-network"}, true},
+		{"not_equals any value", "name", FilterNotEquals, "", false, []interface{}{"dev", "prod-network"}, false},
+		{"not_equals all values", "name", FilterNotEquals, "", false, []interface{}{"dev", "test"}, true},
+		{"not_equals regex", "name", FilterNotEquals, "", true, []interface{}{"^dev-"}, true},
+		{"contains", "name", FilterContains, "", false, []interface{}{"net"}, true},
+		{"prefix", "name", FilterPrefix, "", false, []interface{}{"network"}, false},
+		{"gt numeric", "id", FilterGreater, "", false, []interface{}{"99"}, true},
+		{"lt numeric", "id", FilterLess, "", false, []interface{}{"99"}, false},
+		{"gt datecode", "start_datecode", FilterGreater, "", false, []interface{}{"202312"}, true},
+		{"gt pointer", "azure_policy_id", FilterGreater, "", false, []interface{}{"10"}, true},
+		{"exists", "azure_policy_id", FilterExists, "", false, nil, true},
+		{"exists nil pointer", "payer_id", FilterExists, "", false, nil, false},
+		{"exists missing field", "missing", FilterExists, "", false, nil, false},
+		{"empty string", "description", FilterEmpty, "", false, nil, true},
+		{"empty array", "owner_groups", FilterEmpty, "", false, nil, true},
+		{"empty value", "name", FilterEmpty, "", false, nil, false},
+		{"any owner", "owner_users.id", FilterEquals, FilterMatchAny, false, []interface{}{"100"}, true},
+		{"all owners", "owner_users.id", FilterEquals, FilterMatchAll, false, []interface{}{"100"}, false},
+		{"all owners gt", "owner_users.id", FilterGreater, FilterMatchAll, false, []interface{}{"50"}, true},
+		{"all owners not_equals", "owner_users.id", FilterNotEquals, FilterMatchAll, false, []interface{}{"100"}, false},
+		{"any owner not_equals", "owner_users.id", FilterNotEquals, FilterMatchAny, false, []interface{}{"100"}, false},
+		{"no owner equals", "owner_users.id", FilterNotEquals, FilterMatchAny, false, []interface{}{"5"}, true},
+		{"not_equals no owners", "owner_groups.id", FilterNotEquals, FilterMatchAny, false, []interface{}{"1"}, true},
+		{"all of no owners", "owner_groups.id", FilterEquals, FilterMatchAll, false, []interface{}{"1"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filterable := Filterable{arr: []Filter{{
+				key:        tt.key,
+				keys:       strings.Split(tt.key, "."),
+				values:     tt.values,
+				regex:      tt.regex,
+				operator:   tt.operator,
+				arrayMatch: tt.arrayMatch,
+			}}}
+			v, err := filterable.Match(data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, v)
+		})
+	}
+
+	_, err := (&Filterable{arr: []Filter{{key: "name", keys: []string{"name"}, operator: FilterContains}}}).Match(data)
+	assert.Error(t, err)
+	_, err = (&Filterable{arr: []Filter{{key: "name", keys: []string{"name"}, operator: FilterPrefix, regex: true, values: []interface{}{"p"}}}}).Match(data)
+	assert.Error(t, err)
+}
+
+func TestMatchLabels(t *testing.T) {
+	data := map[string]interface{}{
+		"name":   "prod-network",
+		"labels": map[string]interface{}{"env": "prod", "team": "network"},
+	}
+
+	filterable := Filterable{arr: []Filter{
+		{key: "labels.env", keys: []string{"labels", "env"}, values: []interface{}{"prod"}},
+		{key: "name", keys: []string{"name"}, values: []interface{}{"prod-network"}},
+	}}
+	v, err := filterable.Match(data)
+	assert.NoError(t, err)
+	assert.True(t, v)
+
+	labelFilters, others := filterable.Partition("labels")
+	assert.Len(t, labelFilters.arr, 1)
+	assert.Len(t, others.arr, 1)
+
+	filterable = Filterable{arr: []Filter{
+		{key: "labels.owner", keys: []string{"labels", "owner"}, values: []interface{}{"x"}},
+	}}
+	v, err = filterable.Match(data)
+	assert.NoError(t, err)
+	assert.False(t, v)
+
+	labelFilters, others = filterable.Partition("labels")
+	assert.NotNil(t, labelFilters)
+	assert.Nil(t, others)
+
+	filterable = Filterable{arr: []Filter{
+		{key: "labels.owner", keys: []string{"labels", "owner"}, operator: FilterEmpty},
+		{key: "labels.team", keys: []string{"labels", "team"}, operator: FilterExists},
+	}}
+	v, err = filterable.Match(data)
+	assert.NoError(t, err)
+	assert.True(t, v)
+	// An item without the label differs from every value.
+	filterable = Filterable{arr: []Filter{
+		{key: "labels.owner", keys: []string{"labels", "owner"}, operator: FilterNotEquals, values: []interface{}{"x"}},
+	}}
+	v, err = filterable.Match(data)
+	assert.NoError(t, err)
+	assert.True(t, v)
+
+	filterable = Filterable{arr: []Filter{
+		{key: "labels.owner", keys: []string{"labels", "owner"}, operator: FilterPrefix, values: []interface{}{"x"}},
+	}}
+	v, err = filterable.Match(data)
+	assert.NoError(t, err)
+	assert.False(t, v)
+}
@@ -346,7 +346,7 @@ func AssociationChanged(d *schema.ResourceData, fieldname string) ([]int, []int,
 		}
 	}
 
-	arrUserAdd, arrUserRemove, changed := determineAssociations(newIDs, oldIDs)
+	arrUserAdd, arrUserRemove, changed := DetermineAssociations(newIDs, oldIDs)
 	if changed {
 		isChanged = true
 	}
@@ -381,7 +381,7 @@ func AssociationChangedInt(d *schema.ResourceData, fieldname string) (*int, *int
 // DetermineAssociations will take in a src array (source of truth/repo) and a
 // destination array (Kion application) and then return an array of
 // associations to add (arrAdd) and then remove (arrRemove).
-func determineAssociations(src []int, dest []int) (arrAdd []int, arrRemove []int, isChanged bool) {
+func DetermineAssociations(src []int, dest []int) (arrAdd []int, arrRemove []int, isChanged bool) {
 	mSrc := makeMapFromArray(src)
 	mDest := makeMapFromArray(dest)
 
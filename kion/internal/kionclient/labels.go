@@ -1,12 +1,13 @@
 package kionclient
 
 import (
+	"context"
 	"fmt"
 )
 
 var supportedResourceTypes = []string{"account", "cloud-rule", "funding-source", "ou", "project"}
 
-func PutAppLabelIDs(client *Client, labels *[]AssociateLabel, resourceType string, resourceID string) error {
+func PutAppLabelIDs(ctx context.Context, client *Client, labels *[]AssociateLabel, resourceType string, resourceID string) error {
 	if !IsSupportedResourceType(resourceType) {
 		return fmt.Errorf("Error: %v", "Unsupported resource type for labels")
 	}
@@ -15,9 +16,9 @@ func PutAppLabelIDs(client *Client, labels *[]AssociateLabel, resourceType strin
 		Labels: labels,
 	}
 
-	err := client.PUT(fmt.Sprintf("/v3/%s/%s/labels", resourceType, resourceID), req)
+	err := client.PUTContext(ctx, fmt.Sprintf("/v3/%s/%s/labels", resourceType, resourceID), req)
 	if err != nil {
-		return fmt.Errorf("Error: %v", err)
+		return fmt.Errorf("Error: %w", err)
 	}
 
 	return nil
@@ -32,13 +33,13 @@ func IsSupportedResourceType(resourceType string) bool {
 	return false
 }
 
-func ReadResourceLabels(client *Client, resourceType string, resourceID string) (map[string]interface{}, error) {
+func ReadResourceLabels(ctx context.Context, client *Client, resourceType string, resourceID string) (map[string]interface{}, error) {
 	if !IsSupportedResourceType(resourceType) {
 		return nil, fmt.Errorf("Error: %v", "Unsupported resource type for labels")
 	}
 
 	labelsResp := new(AssociatedLabelsResponse)
-	err := client.GET(fmt.Sprintf("/v3/%s/%s/labels", resourceType, resourceID), labelsResp)
+	err := client.GETAllContext(ctx, fmt.Sprintf("/v3/%s/%s/labels", resourceType, resourceID), labelsResp)
 	if err != nil {
 		return nil, err
 	}
@@ -1,9 +1,9 @@
 package kionclient
 
-// BudgetCreate for: POST /api/v3/project/with-budget
+// BudgetCreate for: POST /api/v3/project/with-budget and POST /api/v3/budget
 type BudgetCreate struct {
 	ProjectID        int                `json:"project_id"`
-	OUID             int                `json:"ou_id"`
+	OUID             int                `json:"ou_id,omitempty"`
 	StartDatecode    string             `json:"start_datecode"`
 	EndDatecode      string             `json:"end_datecode"`
 	Amount           float64            `json:"amount"`
@@ -11,10 +11,41 @@ type BudgetCreate struct {
 	Data             []BudgetDataCreate `json:"data"`
 }
 
-// BudgetDataCreate for: POST /api/v3/project/with-budget
+// BudgetDataCreate for: POST /api/v3/project/with-budget and POST /api/v3/budget
 type BudgetDataCreate struct {
 	Datecode        string  `json:"datecode"`
 	Amount          float64 `json:"amount"`
 	FundingSourceID int     `json:"funding_source_id"`
 	Priority        int     `json:"priority"`
 }
+
+// BudgetUpdate for: PATCH /api/v3/budget/{id}
+type BudgetUpdate struct {
+	StartDatecode    string             `json:"start_datecode"`
+	EndDatecode      string             `json:"end_datecode"`
+	Amount           float64            `json:"amount"`
+	FundingSourceIDs *[]int             `json:"funding_source_ids"`
+	Data             []BudgetDataCreate `json:"data"`
+}
+
+// BudgetResponse for: GET /api/v3/budget/{id}
+type BudgetResponse struct {
+	Data struct {
+		ID            int                `json:"id"`
+		ProjectID     int                `json:"project_id"`
+		OUID          int                `json:"ou_id"`
+		StartDatecode string             `json:"start_datecode"`
+		EndDatecode   string             `json:"end_datecode"`
+		Amount        float64            `json:"amount"`
+		Data          []BudgetDataCreate `json:"data"`
+	} `json:"data"`
+	Status int `json:"status"`
+}
+
+// FinancialConfigResponse for: GET /api/v1/ct-config/financials-config
+type FinancialConfigResponse struct {
+	Data struct {
+		BudgetMode bool `json:"budget_mode"`
+	} `json:"data"`
+	Status int `json:"status"`
+}
@@ -0,0 +1,20 @@
+package kionclient
+
+// LoginRequest for: POST /api/v3/login
+type LoginRequest struct {
+	IDMSID   int    `json:"idms"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoginResponse for: POST /api/v3/login
+type LoginResponse struct {
+	Data struct {
+		UserID int `json:"user_id"`
+		Access struct {
+			Token  string `json:"token"`
+			Expiry string `json:"expiry"`
+		} `json:"access"`
+	} `json:"data"`
+	Status int `json:"status"`
+}
@@ -0,0 +1,19 @@
+package kionclient
+
+// MeResponse for: GET /api/v3/me
+type MeResponse struct {
+	Data struct {
+		ID          int    `json:"id"`
+		Username    string `json:"username"`
+		Email       string `json:"email"`
+		DisplayName string `json:"display_name"`
+		Enabled     bool   `json:"enabled"`
+	} `json:"data"`
+	Status int `json:"status"`
+}
+
+// VersionResponse for: GET /api/version
+type VersionResponse struct {
+	Data   string `json:"data"`
+	Status int    `json:"status"`
+}
@@ -8,3 +8,20 @@ type OUPermissionAdd struct {
 	PostWebhookID     *int   `json:"post_webhook_id"`
 	PreWebhookID      *int   `json:"pre_webhook_id"`
 }
+
+// OUPermissionRemove for: DELETE /v3/ou/{id}/permission-mapping
+type OUPermissionRemove struct {
+	AppRoleID         *int   `json:"app_role_id"`
+	OwnerUserGroupIds *[]int `json:"user_groups_ids"`
+	OwnerUserIds      *[]int `json:"user_ids"`
+}
+
+// OUPermissionMappingListResponse for: GET /v3/ou/{id}/permission-mapping
+type OUPermissionMappingListResponse struct {
+	Data []struct {
+		AppRoleID    int    `json:"app_role_id"`
+		UserGroupIds *[]int `json:"user_groups_ids"`
+		UserIds      *[]int `json:"user_ids"`
+	} `json:"data"`
+	Status int `json:"status"`
+}
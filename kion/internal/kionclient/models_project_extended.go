@@ -1,10 +1,60 @@
 package kionclient
 
-// ProjectFundingCreate for: POST /v3/project
+// ProjectFundingCreate for: POST /v3/project and POST /v3/project-funding
 type ProjectFundingCreate struct {
+	ProjectID       int     `json:"project_id,omitempty"`
 	FundingSourceID int     `json:"funding_source_id"`
 	Amount          float64 `json:"amount"`
 	StartDatecode   string  `json:"start_datecode"`
 	EndDatecode     string  `json:"end_datecode"`
 	FundingOrder    int     `json:"funding_order"`
 }
+
+// ProjectFunding is a funding allocation of a project.
+type ProjectFunding struct {
+	ID              int     `json:"id"`
+	ProjectID       int     `json:"project_id"`
+	FundingSourceID int     `json:"funding_source_id"`
+	Amount          float64 `json:"amount"`
+	StartDatecode   string  `json:"start_datecode"`
+	EndDatecode     string  `json:"end_datecode"`
+	FundingOrder    int     `json:"funding_order"`
+}
+
+// ProjectFundingResponse for: GET /v3/project-funding/{id}
+type ProjectFundingResponse struct {
+	Data   ProjectFunding `json:"data"`
+	Status int            `json:"status"`
+}
+
+// ProjectFundingListResponse for: GET /v3/project/{id}/funding
+type ProjectFundingListResponse struct {
+	Data   []ProjectFunding `json:"data"`
+	Status int              `json:"status"`
+}
+
+// ProjectFundingUpdate for: PATCH /v3/project-funding/{id}
+type ProjectFundingUpdate struct {
+	FundingSourceID int     `json:"funding_source_id"`
+	Amount          float64 `json:"amount"`
+	StartDatecode   string  `json:"start_datecode"`
+	EndDatecode     string  `json:"end_datecode"`
+	FundingOrder    int     `json:"funding_order"`
+}
+
+// ProjectPermissionMapping for: PATCH /v3/project/{id}/permission-mapping
+type ProjectPermissionMapping struct {
+	AppRoleID    int    `json:"app_role_id"`
+	UserGroupIds *[]int `json:"user_groups_ids"`
+	UserIds      *[]int `json:"user_ids"`
+}
+
+// ProjectUserMappingListResponse for: GET /v3/project/{id}/permission-mapping
+type ProjectUserMappingListResponse struct {
+	Data []struct {
+		AppRoleId    int    `json:"app_role_id"`
+		UserGroupIds *[]int `json:"user_groups_ids"`
+		UserIds      *[]int `json:"user_ids"`
+	} `json:"data"`
+	Status int `json:"status"`
+}
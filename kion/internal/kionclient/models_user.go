@@ -0,0 +1,18 @@
+package kionclient
+
+// User is a Kion user as returned by the user list.
+type User struct {
+	ID        int    `json:"id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	IdmsID    int    `json:"idms_id"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// UserListResponse for: GET /api/v3/user
+type UserListResponse struct {
+	Data   []User `json:"data"`
+	Status int    `json:"status"`
+}
@@ -0,0 +1,67 @@
+package kionclient
+
+// Webhook is a webhook that Kion calls, e.g. before or after a cloud rule is
+// applied.
+type Webhook struct {
+	ID                   int    `json:"id"`
+	Name                 string `json:"name"`
+	Description          string `json:"description"`
+	CalloutURL           string `json:"callout_url"`
+	RequestMethod        string `json:"request_method"`
+	RequestHeaders       string `json:"request_headers"`
+	RequestBody          string `json:"request_body"`
+	UseRequestHeaders    bool   `json:"use_request_headers"`
+	ShouldSendSecureInfo bool   `json:"should_send_secure_info"`
+	SkipSSL              bool   `json:"skip_ssl"`
+	TimeoutInSeconds     int    `json:"timeout_in_seconds"`
+}
+
+// WebhookListResponse for: GET /api/v3/webhook
+type WebhookListResponse struct {
+	Data []struct {
+		Webhook         Webhook        `json:"webhook"`
+		OwnerUserGroups []ObjectWithID `json:"owner_user_groups"`
+		OwnerUsers      []ObjectWithID `json:"owner_users"`
+	} `json:"data"`
+	Status int `json:"status"`
+}
+
+// WebhookResponse for: GET /api/v3/webhook/{id}
+type WebhookResponse struct {
+	Data struct {
+		Webhook         Webhook        `json:"webhook"`
+		OwnerUserGroups []ObjectWithID `json:"owner_user_groups"`
+		OwnerUsers      []ObjectWithID `json:"owner_users"`
+	} `json:"data"`
+	Status int `json:"status"`
+}
+
+// WebhookCreate for: POST /api/v3/webhook
+type WebhookCreate struct {
+	Name                 string `json:"name"`
+	Description          string `json:"description"`
+	CalloutURL           string `json:"callout_url"`
+	RequestMethod        string `json:"request_method"`
+	RequestHeaders       string `json:"request_headers"`
+	RequestBody          string `json:"request_body"`
+	UseRequestHeaders    bool   `json:"use_request_headers"`
+	ShouldSendSecureInfo bool   `json:"should_send_secure_info"`
+	SkipSSL              bool   `json:"skip_ssl"`
+	TimeoutInSeconds     int    `json:"timeout_in_seconds,omitempty"`
+	OwnerUserIds         *[]int `json:"owner_user_ids"`
+	OwnerUserGroupIds    *[]int `json:"owner_user_group_ids"`
+}
+
+// WebhookUpdate for: PATCH /api/v3/webhook/{id}
+type WebhookUpdate struct {
+	Name                 string `json:"name"`
+	Description          string `json:"description"`
+	CalloutURL           string `json:"callout_url"`
+	RequestMethod        string `json:"request_method"`
+	RequestHeaders       string `json:"request_headers"`
+	RequestBody          string `json:"request_body"`
+	UseRequestHeaders    bool   `json:"use_request_headers"`
+	ShouldSendSecureInfo bool   `json:"should_send_secure_info"`
+	SkipSSL              bool   `json:"skip_ssl"`
+	TimeoutInSeconds     int    `json:"timeout_in_seconds,omitempty"`
+}
@@ -0,0 +1,114 @@
+package kionclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// page is the envelope of a paginated list response. Lists that Kion does
+// not paginate return the items directly in data.
+type page struct {
+	Status int `json:"status"`
+	Data   struct {
+		Items []json.RawMessage `json:"items"`
+		Total *int              `json:"total"`
+	} `json:"data"`
+}
+
+// GETAllContext retrieves a list from Kion like GETContext. If Kion returns
+// the list in pages of items with a total, the remaining pages are requested
+// with the page and count query parameters until all items are retrieved
+// and returned as a single page.
+func (client *Client) GETAllContext(ctx context.Context, urlPath string, returnData interface{}) error {
+	if v := reflect.ValueOf(returnData); v.Kind() != reflect.Ptr {
+		return errors.New("data must be a pointer, not a value")
+	}
+
+	body, err := client.getBody(ctx, urlPath)
+	if err != nil {
+		return err
+	}
+
+	first, paginated := parsePage(body)
+	if !paginated || len(first.Data.Items) >= *first.Data.Total {
+		return unmarshalBody(body, returnData)
+	}
+
+	total := *first.Data.Total
+	pageSize := len(first.Data.Items)
+	items := first.Data.Items
+	for number := 2; len(items) < total && pageSize > 0; number++ {
+		pagePath, err := withQuery(urlPath, map[string]string{
+			"page":  strconv.Itoa(number),
+			"count": strconv.Itoa(pageSize),
+		})
+		if err != nil {
+			return err
+		}
+
+		body, err := client.getBody(ctx, pagePath)
+		if err != nil {
+			return err
+		}
+		next, ok := parsePage(body)
+		if !ok || len(next.Data.Items) == 0 {
+			break
+		}
+		items = append(items, next.Data.Items...)
+	}
+
+	first.Data.Items = items
+	combined, err := json.Marshal(first)
+	if err != nil {
+		return err
+	}
+	return unmarshalBody(combined, returnData)
+}
+
+// getBody sends a GET request and returns the response body.
+func (client *Client) getBody(ctx context.Context, urlPath string) ([]byte, error) {
+	req, err := client.newRequest(ctx, http.MethodGet, urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, _, err := client.doCachedRequest(req)
+	return body, err
+}
+
+// parsePage decodes body as a page of a paginated list. It reports false if
+// the list is not paginated.
+func parsePage(body []byte) (page, bool) {
+	var p page
+	if err := json.Unmarshal(body, &p); err != nil || p.Data.Total == nil {
+		return page{}, false
+	}
+	return p, true
+}
+
+func unmarshalBody(body []byte, returnData interface{}) error {
+	if err := json.Unmarshal(body, returnData); err != nil {
+		return NewRequestError(http.StatusOK, fmt.Errorf("could not unmarshal response body: %v", string(body)))
+	}
+	return nil
+}
+
+// withQuery sets the given query parameters on urlPath.
+func withQuery(urlPath string, params map[string]string) (string, error) {
+	u, err := url.Parse(urlPath)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	for key, value := range params {
+		q.Set(key, value)
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,60 @@
+package kionclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGETAllContextPaginated(t *testing.T) {
+	const total = 7
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		number, count := 1, 3
+		if v := r.URL.Query().Get("page"); v != "" {
+			number, _ = strconv.Atoi(v)
+			count, _ = strconv.Atoi(r.URL.Query().Get("count"))
+		}
+		assert.Equal(t, "value", r.URL.Query().Get("keep"))
+
+		items := ""
+		for id := (number-1)*count + 1; id <= number*count && id <= total; id++ {
+			if items != "" {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"id": %d, "key": "k%d"}`, id, id)
+		}
+		_, _ = fmt.Fprintf(w, `{"status": 200, "data": {"items": [%s], "total": %d}}`, items, total)
+	}))
+	defer server.Close()
+
+	resp := new(LabelListResponse)
+	err := newTestClient(server).GETAllContext(context.Background(), "/v3/label?keep=value", resp)
+	assert.NoError(t, err)
+	assert.Equal(t, total, resp.Data.Total)
+	assert.Len(t, resp.Data.Items, total)
+	for i, item := range resp.Data.Items {
+		assert.Equal(t, i+1, item.ID)
+	}
+}
+
+func TestGETAllContextNotPaginated(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"status": 200, "data": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]}`))
+	}))
+	defer server.Close()
+
+	resp := new(OUListResponse)
+	err := newTestClient(server).GETAllContext(context.Background(), "/v3/ou", resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Data, 2)
+	assert.Equal(t, 1, calls)
+
+	assert.Error(t, newTestClient(server).GETAllContext(context.Background(), "/v3/ou", OUListResponse{}))
+}
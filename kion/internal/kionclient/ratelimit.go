@@ -0,0 +1,99 @@
+package kionclient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket that allows up to rate requests per second
+// with bursts of up to burst requests.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64) *rateLimiter {
+	burst := math.Max(1, math.Ceil(rate))
+	return &rateLimiter{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// SetRateLimit limits the client to the given number of requests per second
+// across all goroutines. A value of 0 or less removes the limit.
+func (client *Client) SetRateLimit(requestsPerSecond float64) {
+	if requestsPerSecond <= 0 {
+		client.limiter = nil
+		return
+	}
+	client.limiter = newRateLimiter(requestsPerSecond)
+}
+
+// SetMaxConcurrentRequests caps the number of requests in flight at the same
+// time. A value of 0 or less removes the cap.
+func (client *Client) SetMaxConcurrentRequests(n int) {
+	if n <= 0 {
+		client.inFlight = nil
+		return
+	}
+	client.inFlight = make(chan struct{}, n)
+}
+
+// acquire waits for a free request slot and a rate limit token. The returned
+// function releases the slot and must always be called.
+func (client *Client) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+
+	if client.inFlight != nil {
+		select {
+		case client.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return release, ctx.Err()
+		}
+		release = func() { <-client.inFlight }
+	}
+
+	if client.limiter != nil {
+		if err := client.limiter.Wait(ctx); err != nil {
+			release()
+			return func() {}, err
+		}
+	}
+
+	return release, nil
+}
@@ -0,0 +1,87 @@
+package kionclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Collections that resources reference by ID, see ReferenceExists.
+const (
+	ReferenceCloudRule        = "/v3/cloud-rule"
+	ReferenceFundingSource    = "/v3/funding-source"
+	ReferenceOU               = "/v3/ou"
+	ReferencePayer            = "/v3/billing-source"
+	ReferencePermissionScheme = "/v3/permission-scheme"
+	ReferenceProject          = "/v3/project"
+	ReferenceUser             = "/v3/user"
+	ReferenceUserGroup        = "/v3/user-group"
+	ReferenceWebhook          = "/v3/webhook"
+)
+
+// references remembers which referenced objects exist, so each object is
+// only looked up once per Terraform run.
+type references struct {
+	mu        sync.Mutex
+	exists    map[string]bool
+	labelKeys map[string]bool
+}
+
+// ReferenceExists reports whether the object with the given ID exists in a
+// collection such as ReferenceOU.
+func (client *Client) ReferenceExists(ctx context.Context, collection string, id int) (bool, error) {
+	refs := client.referenceCache()
+	key := fmt.Sprintf("%s/%d", collection, id)
+
+	refs.mu.Lock()
+	exists, ok := refs.exists[key]
+	refs.mu.Unlock()
+	if ok {
+		return exists, nil
+	}
+
+	err := client.GETContext(ctx, key, nil)
+	if err != nil && !IsNotFound(err) {
+		return false, err
+	}
+	exists = err == nil
+
+	refs.mu.Lock()
+	refs.exists[key] = exists
+	refs.mu.Unlock()
+
+	return exists, nil
+}
+
+// LabelKeyExists reports whether a label with the given key exists.
+func (client *Client) LabelKeyExists(ctx context.Context, key string) (bool, error) {
+	refs := client.referenceCache()
+
+	refs.mu.Lock()
+	labelKeys := refs.labelKeys
+	refs.mu.Unlock()
+
+	if labelKeys == nil {
+		resp := new(LabelListResponse)
+		if err := client.GETAllContext(ctx, "/v3/label", resp); err != nil {
+			return false, err
+		}
+		labelKeys = make(map[string]bool)
+		for _, item := range resp.Data.Items {
+			labelKeys[item.Key] = true
+		}
+
+		refs.mu.Lock()
+		refs.labelKeys = labelKeys
+		refs.mu.Unlock()
+	}
+
+	return labelKeys[key], nil
+}
+
+func (client *Client) referenceCache() *references {
+	client.referencesOnce.Do(func() {
+		client.references = &references{exists: make(map[string]bool)}
+	})
+	return client.references
+}
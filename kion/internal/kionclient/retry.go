@@ -0,0 +1,106 @@
+package kionclient
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a failed request is retried
+	// when the provider configuration does not specify otherwise.
+	DefaultMaxRetries = 4
+
+	// DefaultRetryMaxWait is the longest the client waits between two
+	// attempts when the provider configuration does not specify otherwise.
+	DefaultRetryMaxWait = 30 * time.Second
+
+	// retryBaseWait is the delay before the first retry. It doubles on every
+	// following attempt until RetryMaxWait is reached.
+	retryBaseWait = 1 * time.Second
+)
+
+// isIdempotent reports whether a request with the given method can be
+// replayed without risking a duplicate change in Kion.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry determines if a request should be attempted again based on the
+// transport error or the response status code. POST and PATCH requests are
+// only retried when the request provably was not processed: it was rate
+// limited, or the connection to Kion could not be established.
+func shouldRetry(method string, statusCode int, err error) bool {
+	if err != nil {
+		// A failed dial means the request never left the client.
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return isIdempotent(method)
+	}
+
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+
+	return false
+}
+
+// retryWait returns how long to wait before the given retry attempt
+// (starting at 0). A Retry-After header sent by Kion takes precedence over
+// the exponential backoff, but neither may exceed RetryMaxWait.
+func (client *Client) retryWait(attempt int, res *http.Response) time.Duration {
+	maxWait := client.RetryMaxWait
+	if maxWait <= 0 {
+		maxWait = DefaultRetryMaxWait
+	}
+
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			return time.Duration(math.Min(float64(wait), float64(maxWait)))
+		}
+	}
+
+	wait := time.Duration(math.Min(float64(retryBaseWait)*math.Pow(2, float64(attempt)), float64(maxWait)))
+
+	// Use "equal jitter" so parallel resources don't retry in lockstep while
+	// still waiting at least half of the computed backoff.
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if wait := t.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
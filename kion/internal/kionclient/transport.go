@@ -0,0 +1,76 @@
+package kionclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// SetTLSConfig trusts the certificate authorities in caCertPEM in addition
+// to the system pool and, if clientCertPEM and clientKeyPEM are given,
+// presents that certificate to Kion for mutual TLS. Empty values are
+// ignored.
+func (client *Client) SetTLSConfig(caCertPEM, clientCertPEM, clientKeyPEM []byte) error {
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		return errors.New("the HTTP transport of the client does not support TLS settings")
+	}
+
+	tlsConfig := transport.TLSClientConfig.Clone()
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+
+	if len(caCertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCertPEM) {
+			return errors.New("the CA bundle does not contain any PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(clientCertPEM) > 0 || len(clientKeyPEM) > 0 {
+		if len(clientCertPEM) == 0 || len(clientKeyPEM) == 0 {
+			return errors.New("both a client certificate and a client key are required for mutual TLS")
+		}
+		cert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+		if err != nil {
+			return fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return nil
+}
+
+// SetProxy sends requests through proxyURL, except for the hosts matched by
+// noProxy, a comma separated list in the format of the NO_PROXY environment
+// variable. Credentials in proxyURL are sent to the proxy.
+func (client *Client) SetProxy(proxyURL *url.URL, noProxy string) error {
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		return errors.New("the HTTP transport of the client does not support proxy settings")
+	}
+	if proxyURL.Scheme == "" || proxyURL.Host == "" {
+		return fmt.Errorf("the proxy URL is not valid: %s, expected an absolute URL such as http://proxy.example.com:3128", proxyURL.Redacted())
+	}
+
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  proxyURL.String(),
+		HTTPSProxy: proxyURL.String(),
+		NoProxy:    noProxy,
+	}).ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+	return nil
+}
@@ -0,0 +1,436 @@
+package kion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+// Attributes that reference owners by name instead of by ID.
+const (
+	ownerUserEmails     = "owner_user_emails"
+	ownerUsernames      = "owner_usernames"
+	ownerUserGroupNames = "owner_user_group_names"
+)
+
+// withOwnerNames lets a resource reference its owners by username, email or
+// user group name as an alternative to the ID blocks in the users and
+// userGroups attributes. The names are resolved to IDs while planning, or
+// during apply when they are not known yet, and both forms are kept in the
+// state. Resources that only use IDs never list users or user groups.
+func withOwnerNames(users, userGroups string, r *schema.Resource) *schema.Resource {
+	all := []string{userGroups, users, ownerUserEmails, ownerUsernames, ownerUserGroupNames}
+	description := fmt.Sprintf("Must provide at least one of the %s, %s, %s, %s or %s fields.", userGroups, users, ownerUserEmails, ownerUsernames, ownerUserGroupNames)
+
+	for _, attr := range []string{users, userGroups} {
+		s := r.Schema[attr]
+		s.Computed = true
+		s.AtLeastOneOf = all
+		s.Description = description
+	}
+	r.Schema[users].ConflictsWith = []string{ownerUserEmails, ownerUsernames}
+	r.Schema[userGroups].ConflictsWith = []string{ownerUserGroupNames}
+
+	r.Schema[ownerUserEmails] = &schema.Schema{
+		Description:   fmt.Sprintf("The email addresses of the owner users, an alternative to %s. %s", users, description),
+		Type:          schema.TypeSet,
+		Elem:          &schema.Schema{Type: schema.TypeString},
+		Optional:      true,
+		Computed:      true,
+		AtLeastOneOf:  all,
+		ConflictsWith: []string{users, ownerUsernames},
+	}
+	r.Schema[ownerUsernames] = &schema.Schema{
+		Description:   fmt.Sprintf("The usernames of the owner users, an alternative to %s. %s", users, description),
+		Type:          schema.TypeSet,
+		Elem:          &schema.Schema{Type: schema.TypeString},
+		Optional:      true,
+		Computed:      true,
+		AtLeastOneOf:  all,
+		ConflictsWith: []string{users, ownerUserEmails},
+	}
+	r.Schema[ownerUserGroupNames] = &schema.Schema{
+		Description:   fmt.Sprintf("The names of the owner user groups, an alternative to %s. %s", userGroups, description),
+		Type:          schema.TypeSet,
+		Elem:          &schema.Schema{Type: schema.TypeString},
+		Optional:      true,
+		Computed:      true,
+		AtLeastOneOf:  all,
+		ConflictsWith: []string{userGroups},
+	}
+
+	planOwners := func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		return planOwnerNames(ctx, d, m, users, userGroups)
+	}
+	if r.CustomizeDiff != nil {
+		r.CustomizeDiff = customdiff.All(planOwners, r.CustomizeDiff)
+	} else {
+		r.CustomizeDiff = planOwners
+	}
+
+	wrap := func(apply func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var diags diag.Diagnostics
+			if err := resolveOwnerNames(ctx, d, m.(*hc.Client), users, userGroups); err != nil {
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to resolve owners", err)...)
+				return diags
+			}
+			diags = append(diags, apply(ctx, d, m)...)
+			if diags.HasError() || d.Id() == "" {
+				return diags
+			}
+			return append(diags, setOwnerNames(ctx, d, m.(*hc.Client), users, userGroups)...)
+		}
+	}
+	r.CreateContext = wrap(r.CreateContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+
+	read := r.ReadContext
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := read(ctx, d, m)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		return append(diags, setOwnerNames(ctx, d, m.(*hc.Client), users, userGroups)...)
+	}
+
+	return r
+}
+
+// ownerNameAttributes maps the name attributes to the ID attribute they
+// resolve to.
+func ownerNameAttributes(users, userGroups string) map[string]string {
+	return map[string]string{
+		ownerUserEmails:     users,
+		ownerUsernames:      users,
+		ownerUserGroupNames: userGroups,
+	}
+}
+
+// planOwnerNames resolves changed owner names to IDs so the plan shows the
+// owners that will be added and removed. When the names are not known yet,
+// the IDs are resolved during apply. Owners removed from the configuration are
+// planned for removal.
+func planOwnerNames(ctx context.Context, d *schema.ResourceDiff, m interface{}, users, userGroups string) error {
+	// The ID and name attributes are computed so one form can be set from the
+	// other. Owners that are no longer configured in either form are removed
+	// instead of being kept from the state.
+	for _, idAttr := range []string{users, userGroups} {
+		if d.Id() == "" || configuredInDiff(d, idAttr) {
+			continue
+		}
+		inUse := false
+		for nameAttr, attr := range ownerNameAttributes(users, userGroups) {
+			if attr == idAttr && configuredInDiff(d, nameAttr) {
+				inUse = true
+			}
+		}
+		if inUse {
+			continue
+		}
+		if err := d.SetNew(idAttr, []interface{}{}); err != nil {
+			return err
+		}
+	}
+	for nameAttr := range ownerNameAttributes(users, userGroups) {
+		if d.Id() != "" && !configuredInDiff(d, nameAttr) && d.Get(nameAttr).(*schema.Set).Len() > 0 {
+			if err := d.SetNew(nameAttr, []interface{}{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	client, ok := m.(*hc.Client)
+	if !ok {
+		return nil
+	}
+
+	for nameAttr, idAttr := range ownerNameAttributes(users, userGroups) {
+		if _, ok := d.GetOk(nameAttr); !ok || !d.HasChange(nameAttr) {
+			continue
+		}
+		if !d.NewValueKnown(nameAttr) {
+			if err := d.SetNewComputed(idAttr); err != nil {
+				return err
+			}
+			continue
+		}
+		ids, err := resolveOwnerIDs(ctx, client, nameAttr, d.Get(nameAttr).(*schema.Set))
+		if err != nil {
+			return err
+		}
+		if err := d.SetNew(idAttr, hc.InflateArrayOfIDs(ids)); err != nil {
+			return err
+		}
+	}
+
+	// Changed IDs change the names in use, which are read back after apply.
+	for nameAttr, idAttr := range ownerNameAttributes(users, userGroups) {
+		if d.Id() != "" && d.HasChange(idAttr) && !d.HasChange(nameAttr) && d.Get(nameAttr).(*schema.Set).Len() > 0 {
+			if err := d.SetNewComputed(nameAttr); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// configuredInDiff reports whether attr is set in the configuration. Unknown
+// values count as set.
+func configuredInDiff(d *schema.ResourceDiff, attr string) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().HasAttribute(attr) {
+		return true
+	}
+	v := raw.GetAttr(attr)
+	return !v.IsKnown() || (!v.IsNull() && v.LengthInt() > 0)
+}
+
+// resolveOwnerNames sets the ID attributes from the configured owner names.
+func resolveOwnerNames(ctx context.Context, d *schema.ResourceData, client *hc.Client, users, userGroups string) error {
+	for nameAttr, idAttr := range ownerNameAttributes(users, userGroups) {
+		if !isConfigured(d, nameAttr) {
+			continue
+		}
+		v, ok := d.GetOk(nameAttr)
+		if !ok {
+			continue
+		}
+		ids, err := resolveOwnerIDs(ctx, client, nameAttr, v.(*schema.Set))
+		if err != nil {
+			return err
+		}
+		if err := d.Set(idAttr, hc.InflateArrayOfIDs(ids)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveOwnerIDs looks up the IDs of the users or user groups named in the
+// nameAttr attribute. Names that match no object, or more than one, are
+// errors.
+func resolveOwnerIDs(ctx context.Context, client *hc.Client, nameAttr string, names *schema.Set) ([]int, error) {
+	var objects []ownerObject
+	var err error
+	if nameAttr == ownerUserGroupNames {
+		objects, err = listOwnerUserGroups(ctx, client)
+	} else {
+		objects, err = listOwnerUsers(ctx, client)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	var problems []string
+	for _, v := range names.List() {
+		name := v.(string)
+		var matches []int
+		for _, object := range objects {
+			if value := object.lookup(nameAttr); value != "" && strings.EqualFold(value, name) {
+				matches = append(matches, object.id)
+			}
+		}
+		switch len(matches) {
+		case 1:
+			ids = append(ids, matches[0])
+		case 0:
+			problems = append(problems, fmt.Sprintf("%q matches no %s", name, ownerKind(nameAttr)))
+		default:
+			problems = append(problems, fmt.Sprintf("%q matches %d %ss, use their IDs instead", name, len(matches), ownerKind(nameAttr)))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("%s: %s", nameAttr, strings.Join(problems, "; "))
+	}
+
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// setOwnerNames sets the name attributes that are in use, i.e. configured or
+// in the state, from the owner IDs. Owners are only listed for those
+// attributes, and names that match the current ones except for case are kept
+// as they are. When the API key may not list users or user groups, the names
+// in the state are kept.
+func setOwnerNames(ctx context.Context, d *schema.ResourceData, client *hc.Client, users, userGroups string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	listed := make(map[string][]ownerObject)
+	list := func(nameAttr string) ([]ownerObject, error) {
+		kind := ownerKind(nameAttr)
+		if objects, ok := listed[kind]; ok {
+			return objects, nil
+		}
+
+		var objects []ownerObject
+		var err error
+		if nameAttr == ownerUserGroupNames {
+			objects, err = listOwnerUserGroups(ctx, client)
+		} else {
+			objects, err = listOwnerUsers(ctx, client)
+		}
+		if err != nil {
+			return nil, err
+		}
+		listed[kind] = objects
+		return objects, nil
+	}
+
+	for nameAttr, idAttr := range ownerNameAttributes(users, userGroups) {
+		current := d.Get(nameAttr).(*schema.Set)
+		if current.Len() == 0 {
+			continue
+		}
+
+		objects, err := list(nameAttr)
+		if err != nil {
+			if hc.IsForbidden(err) {
+				tflog.Warn(ctx, "Not allowed to list owners, keeping the owner names in the state", map[string]interface{}{"attribute": nameAttr})
+				continue
+			}
+			diags = append(diags, apiErrorDiagnostics(d, fmt.Sprintf("Unable to read owner %ss", ownerKind(nameAttr)), err)...)
+			return diags
+		}
+
+		ids := make(map[int]bool)
+		collectIDs(d.Get(idAttr), []string{"id"}, ids)
+
+		names := make([]interface{}, 0)
+		for _, object := range objects {
+			name := object.lookup(nameAttr)
+			if !ids[object.id] || name == "" {
+				continue
+			}
+			for _, v := range current.List() {
+				if strings.EqualFold(v.(string), name) {
+					name = v.(string)
+					break
+				}
+			}
+			names = append(names, name)
+		}
+		if err := d.Set(nameAttr, names); err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to set owner names", err)...)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// ownerObject is a user or user group that can own a resource.
+type ownerObject struct {
+	id    int
+	name  string
+	email string
+}
+
+// lookup returns the value nameAttr refers to the object by.
+func (o ownerObject) lookup(nameAttr string) string {
+	if nameAttr == ownerUserEmails {
+		return o.email
+	}
+	return o.name
+}
+
+func ownerKind(nameAttr string) string {
+	if nameAttr == ownerUserGroupNames {
+		return "user group"
+	}
+	return "user"
+}
+
+func listOwnerUsers(ctx context.Context, client *hc.Client) ([]ownerObject, error) {
+	resp := new(hc.UserListResponse)
+	if err := client.GETAllContext(ctx, "/v3/user", resp); err != nil {
+		return nil, err
+	}
+
+	objects := make([]ownerObject, 0, len(resp.Data))
+	for _, item := range resp.Data {
+		objects = append(objects, ownerObject{id: item.ID, name: item.Username, email: item.Email})
+	}
+	return objects, nil
+}
+
+func listOwnerUserGroups(ctx context.Context, client *hc.Client) ([]ownerObject, error) {
+	resp := new(hc.UGroupListResponse)
+	if err := client.GETAllContext(ctx, "/v3/user-group", resp); err != nil {
+		return nil, err
+	}
+
+	objects := make([]ownerObject, 0, len(resp.Data))
+	for _, item := range resp.Data {
+		objects = append(objects, ownerObject{id: item.ID, name: item.Name})
+	}
+	return objects, nil
+}
+
+// ownerDetails exposes the owners of the item a data source matched in single
+// object mode. read returns the owner users and user groups of the item with
+// the given ID as lists of objects with an id.
+func ownerDetails(read func(ctx context.Context, client *hc.Client, id string) ([]interface{}, []interface{}, error)) singleItemDetails {
+	owners := &schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+			},
+		},
+	}
+
+	return singleItemDetails{
+		schema: map[string]*schema.Schema{
+			"owner_user_groups": owners,
+			"owner_users":       owners,
+		},
+		read: func(ctx context.Context, client *hc.Client, item map[string]interface{}) error {
+			users, userGroups, err := read(ctx, client, fmt.Sprint(item["id"]))
+			if err != nil {
+				return err
+			}
+			item["owner_users"] = users
+			item["owner_user_groups"] = userGroups
+			return nil
+		},
+	}
+}
+
+// readPermissionMappingOwners returns the users and user groups that hold the
+// owner app role in the permission mapping at urlPath, e.g.
+// /v3/funding-source/{id}/permission-mapping.
+func readPermissionMappingOwners(ctx context.Context, client *hc.Client, urlPath string) ([]interface{}, []interface{}, error) {
+	resp := new(hc.FSUserMappingListResponse)
+	if err := client.GETContext(ctx, urlPath, resp); err != nil {
+		return nil, nil, err
+	}
+
+	var userIDs, userGroupIDs []int
+	for _, item := range resp.Data {
+		if item.AppRoleId != 1 {
+			continue
+		}
+		if item.UserIds != nil {
+			userIDs = append(userIDs, *item.UserIds...)
+		}
+		if item.UserGroupIds != nil {
+			userGroupIDs = append(userGroupIDs, *item.UserGroupIds...)
+		}
+	}
+
+	return hc.InflateArrayOfIDs(userIDs), hc.InflateArrayOfIDs(userGroupIDs), nil
+}
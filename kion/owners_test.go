@@ -0,0 +1,148 @@
+package kion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/gocty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnerNames(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/user":
+			_, _ = w.Write([]byte(`{"status": 200, "data": [
+				{"id": 1, "username": "alice", "email": "alice@example.com"},
+				{"id": 2, "username": "bob", "email": "bob@example.com"},
+				{"id": 3, "username": "bob", "email": ""}
+			]}`))
+		case "/api/v3/user-group":
+			_, _ = w.Write([]byte(`{"status": 200, "data": [{"id": 5, "name": "Admins"}, {"id": 6, "name": "Developers"}]}`))
+		}
+	})
+
+	ctx := context.Background()
+	names := func(v ...interface{}) *schema.Set { return schema.NewSet(schema.HashString, v) }
+
+	ids, err := resolveOwnerIDs(ctx, client, ownerUserEmails, names("Bob@example.com", "alice@example.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+
+	ids, err = resolveOwnerIDs(ctx, client, ownerUserGroupNames, names("developers"))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{6}, ids)
+
+	_, err = resolveOwnerIDs(ctx, client, ownerUsernames, names("bob", "carol"))
+	assert.ErrorContains(t, err, `"bob" matches 2 users`)
+	assert.ErrorContains(t, err, `"carol" matches no user`)
+
+	r := resourceOU()
+	d := r.TestResourceData()
+	d.SetId("1")
+	assert.NoError(t, d.Set("owner_users", hc.InflateArrayOfIDs([]int{1, 3})))
+	assert.NoError(t, d.Set("owner_user_groups", hc.InflateArrayOfIDs([]int{5})))
+	assert.NoError(t, d.Set(ownerUsernames, []interface{}{"ALICE"}))
+	assert.NoError(t, d.Set(ownerUserGroupNames, []interface{}{"admins"}))
+
+	// Names keep the case they were configured with, and only the name
+	// attributes in use are set.
+	diags := setOwnerNames(ctx, d, client, "owner_users", "owner_user_groups")
+	assert.False(t, diags.HasError())
+	assert.ElementsMatch(t, []interface{}{"ALICE", "bob"}, d.Get(ownerUsernames).(*schema.Set).List())
+	assert.Empty(t, d.Get(ownerUserEmails).(*schema.Set).List())
+	assert.ElementsMatch(t, []interface{}{"admins"}, d.Get(ownerUserGroupNames).(*schema.Set).List())
+}
+
+func TestOwnerNamesNotInUse(t *testing.T) {
+	status := http.StatusOK
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"status": 403, "message": "forbidden"}`))
+	})
+
+	ctx := context.Background()
+	d := resourceOU().TestResourceData()
+	d.SetId("1")
+	assert.NoError(t, d.Set("owner_users", hc.InflateArrayOfIDs([]int{1})))
+
+	// Resources that only use IDs don't list users or user groups.
+	diags := setOwnerNames(ctx, d, client, "owner_users", "owner_user_groups")
+	assert.False(t, diags.HasError())
+	assert.Equal(t, 0, requests)
+
+	// The names in the state are kept when the API key may not list users.
+	status = http.StatusForbidden
+	assert.NoError(t, d.Set(ownerUsernames, []interface{}{"alice"}))
+	diags = setOwnerNames(ctx, d, client, "owner_users", "owner_user_groups")
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 1, requests)
+	assert.ElementsMatch(t, []interface{}{"alice"}, d.Get(ownerUsernames).(*schema.Set).List())
+}
+
+func TestPlanOwnerRemoval(t *testing.T) {
+	r := resourceOU()
+	ctx := context.Background()
+
+	// The state holds a user and a user group, the configuration only the
+	// user.
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"id":                     "1",
+			"name":                   "ou",
+			"parent_ou_id":           "2",
+			"permission_scheme_id":   "3",
+			"owner_users.#":          "1",
+			"owner_users.0.id":       "4",
+			"owner_user_groups.#":    "1",
+			"owner_user_groups.0.id": "5",
+		},
+	}
+	config := map[string]interface{}{
+		"name":                 "ou",
+		"parent_ou_id":         2,
+		"permission_scheme_id": 3,
+		"owner_users":          []interface{}{map[string]interface{}{"id": 4}},
+	}
+	state.RawConfig = testRawConfig(t, r, config)
+
+	diff, err := r.SimpleDiff(ctx, state, terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, diff.Attributes["owner_user_groups.#"])
+	assert.Equal(t, "0", diff.Attributes["owner_user_groups.#"].New)
+	assert.Nil(t, diff.Attributes["owner_users.#"])
+
+	// User groups referenced by name keep their IDs.
+	config[ownerUserGroupNames] = []interface{}{"admins"}
+	state.RawConfig = testRawConfig(t, r, config)
+	diff, err = r.SimpleDiff(ctx, state, terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, diff.Attributes["owner_user_groups.#"])
+}
+
+// testRawConfig returns config as the configuration value Terraform sends for
+// r. Attributes that are not in config are null.
+func testRawConfig(t *testing.T, r *schema.Resource, config map[string]interface{}) cty.Value {
+	t.Helper()
+	ty := r.CoreConfigSchema().ImpliedType()
+	vals := make(map[string]cty.Value)
+	for name, attrTy := range ty.AttributeTypes() {
+		vals[name] = cty.NullVal(attrTy)
+		if v, ok := config[name]; ok {
+			val, err := gocty.ToCtyValue(v, attrTy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			vals[name] = val
+		}
+	}
+	return cty.ObjectVal(vals)
+}
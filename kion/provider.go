@@ -3,31 +3,178 @@ package kion
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 var awsAccountCreationMux sync.Mutex
 
+// New returns a function that creates the provider, reporting version in the
+// User-Agent header of requests sent to Kion.
+func New(version string) func() *schema.Provider {
+	return func() *schema.Provider {
+		p := Provider()
+		p.ConfigureContextFunc = configure(version, p)
+		return p
+	}
+}
+
 // Provider - Returns a new Terraform provider
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"apikey": {
-				Description: "The API key generated from Kion. Example: app_1_XXXXXXXXXXXX.",
-				Type:        schema.TypeString,
-				Sensitive:   true,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("KION_APIKEY", nil),
+				Description:   "The API key generated from Kion. Example: app_1_XXXXXXXXXXXX. Can be read from the Kion CLI configuration file.",
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("KION_APIKEY", nil),
+				ConflictsWith: []string{"token", "username"},
 			},
 			"apipath": {
 				Description: "The base path of the API. Defaults to /api",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "/api",
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^/[^?#]*$`),
+					"must be an absolute path such as /api",
+				),
+			},
+			"ca_cert_file": {
+				Description:   "Path to a PEM encoded CA bundle used to verify the certificate of Kion, in addition to the system trust store.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("KION_CA_CERT_FILE", nil),
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": {
+				Description:   "PEM encoded CA bundle used to verify the certificate of Kion, in addition to the system trust store.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("KION_CA_CERT_PEM", nil),
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"client_cert": {
+				Description:  "PEM encoded client certificate, or the path to one, presented to Kion for mutual TLS. Requires client_key.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KION_CLIENT_CERT", nil),
+				RequiredWith: []string{"client_key"},
+			},
+			"client_key": {
+				Description:  "PEM encoded private key of the client certificate, or the path to one. Requires client_cert.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("KION_CLIENT_KEY", nil),
+				RequiredWith: []string{"client_cert"},
+			},
+			"config_file": {
+				Description: "Path to the Kion CLI configuration file that url, apikey and skipsslvalidation are read from when they are not set on the provider or in environment variables. Defaults to ~/.kion.yml.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KION_CONFIG_FILE", nil),
+			},
+			"disable_cache": {
+				Description: "If true, list requests are always sent to Kion instead of being answered from the in-memory cache kept during a Terraform run.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KION_DISABLE_CACHE", false),
+			},
+			"extra_headers": {
+				Description: "Additional HTTP headers sent with every request to Kion, e.g. a header required by a web application firewall.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"idms_id": {
+				Description: "The ID of the identity management system (IDMS) in Kion that username belongs to. Defaults to 1, the local IDMS.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KION_IDMS_ID", 1),
+			},
+			"max_concurrent_requests": {
+				Description:  "The maximum number of requests sent to Kion at the same time. Defaults to 0 (unlimited).",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KION_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_requests_per_second": {
+				Description:  "The maximum number of requests per second sent to Kion. Defaults to 0 (unlimited).",
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KION_MAX_REQUESTS_PER_SECOND", 0.0),
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"max_retries": {
+				Description:  "The maximum number of times a request that failed with a transient error (e.g. 429, 502, 503) is retried. POST and PATCH requests are only retried after a 429 or a failed connection. Defaults to 4.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KION_MAX_RETRIES", kionclient.DefaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"no_proxy": {
+				Description:  "Comma separated list of hosts, domains and CIDR ranges that are reached without the proxy. Only used with proxy_url.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KION_NO_PROXY", nil),
+				RequiredWith: []string{"proxy_url"},
+			},
+			"password": {
+				Description: "The password of username.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("KION_PASSWORD", nil),
+			},
+			"profile": {
+				Description: "The profile of the Kion CLI configuration file to use. Defaults to the settings at the top level of the file.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KION_PROFILE", nil),
+			},
+			"proxy_password": {
+				Description:  "The password to authenticate to the proxy with.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("KION_PROXY_PASSWORD", nil),
+				RequiredWith: []string{"proxy_username"},
+			},
+			"proxy_url": {
+				Description:  "The URL of the HTTP proxy used to reach Kion, e.g. http://proxy.example.com:3128. Credentials may be embedded in the URL. Defaults to the HTTPS_PROXY and HTTP_PROXY environment variables.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KION_PROXY_URL", nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			"proxy_username": {
+				Description:  "The username to authenticate to the proxy with. Overrides credentials embedded in proxy_url.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KION_PROXY_USERNAME", nil),
+				RequiredWith: []string{"proxy_url"},
+			},
+			"retry_max_wait": {
+				Description:  "The maximum number of seconds to wait between two retries of a request. Defaults to 30.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KION_RETRY_MAX_WAIT", int(kionclient.DefaultRetryMaxWait/time.Second)),
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 			"skipsslvalidation": {
 				Description: "If true, will skip SSL validation.",
@@ -35,11 +182,39 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("KION_SKIPSSLVALIDATION", nil),
 			},
+			"token": {
+				Description:   "A pre-issued bearer token, e.g. a session token obtained through SAML, used instead of apikey. Kion rejects the token once it expires.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("KION_TOKEN", nil),
+				ConflictsWith: []string{"apikey", "username"},
+			},
 			"url": {
-				Description: "The URL of a Kion installation. Example: https://kion.example.com.",
+				Description:  "The URL of a Kion installation. Example: https://kion.example.com. Can be read from the Kion CLI configuration file.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KION_URL", nil),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"user_agent": {
+				Description: "Text appended to the User-Agent header, which always includes the Terraform and provider versions.",
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("KION_URL", nil),
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KION_USER_AGENT", nil),
+			},
+			"username": {
+				Description:   "The username to log in to Kion with instead of apikey. The provider exchanges username and password for a short-lived session token and logs in again when it expires.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("KION_USERNAME", nil),
+				ConflictsWith: []string{"apikey", "token"},
+			},
+			"validate_references": {
+				Description: "If true, plans fail when a resource references an ID, such as ou_id, payer_id, permission_scheme_id, funding_source_id, cloud_rule_id, an owner user or user group, or a label key, that does not exist in Kion. Only values known at plan time are checked. Each check sends a request to Kion.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KION_VALIDATE_REFERENCES", false),
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
@@ -59,12 +234,17 @@ func Provider() *schema.Provider {
 			"kion_label":                       resourceLabel(),
 			"kion_ou":                          resourceOU(),
 			"kion_ou_cloud_access_role":        resourceOUCloudAccessRole(),
+			"kion_ou_permission_mapping":       resourceOUPermissionMapping(),
 			"kion_project":                     resourceProject(),
+			"kion_project_budget":              resourceProjectBudget(),
 			"kion_project_cloud_access_role":   resourceProjectCloudAccessRole(),
 			"kion_project_enforcement":         resourceProjectEnforcement(),
+			"kion_project_funding":             resourceProjectFunding(),
+			"kion_project_permission_mapping":  resourceProjectPermissionMapping(),
 			"kion_saml_group_association":      resourceSamlGroupAssociation(),
 			"kion_service_control_policy":      resourceServiceControlPolicy(),
 			"kion_user_group":                  resourceUserGroup(),
+			"kion_webhook":                     resourceWebhook(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"kion_account":                     dataSourceAccount(),
@@ -81,17 +261,30 @@ func Provider() *schema.Provider {
 			"kion_gcp_iam_role":                dataSourceGcpIamRole(),
 			"kion_label":                       dataSourceLabel(),
 			"kion_ou":                          dataSourceOU(),
+			"kion_ou_permission_mapping":       dataSourceOUPermissionMapping(),
 			"kion_project":                     dataSourceProject(),
 			"kion_project_enforcement":         dataSourceProjectEnforcement(),
 			"kion_saml_group_association":      dataSourceSamlGroupAssociation(),
 			"kion_service_control_policy":      dataServiceControlPolicy(),
 			"kion_user_group":                  dataSourceUserGroup(),
+			"kion_webhook":                     dataSourceWebhook(),
 		},
-		ConfigureContextFunc: providerConfigure,
 	}
+	p.ConfigureContextFunc = configure("dev", p)
+	return p
 }
 
-func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func configure(version string, p *schema.Provider) schema.ConfigureContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		userAgent := p.UserAgent("terraform-provider-kion", version)
+		if extra := d.Get("user_agent").(string); extra != "" {
+			userAgent += " " + extra
+		}
+		return providerConfigure(ctx, d, userAgent)
+	}
+}
+
+func providerConfigure(ctx context.Context, d *schema.ResourceData, userAgent string) (interface{}, diag.Diagnostics) {
 	kionURL := d.Get("url").(string)
 	kionAPIKey := d.Get("apikey").(string)
 	kionAPIPath := d.Get("apipath").(string)
@@ -106,17 +299,341 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		skipSSLValidation = t
 	}
 
-	client := kionclient.NewClient(kionURL, kionAPIKey, kionAPIPath, skipSSLValidation)
-	err := client.GET("/v3/me/cloud-access-role", nil)
-	if err != nil {
+	username := d.Get("username").(string)
+	password := d.Get("password").(string)
+	idmsID := d.Get("idms_id").(int)
+	token := d.Get("token").(string)
+	hasCredentials := kionAPIKey != "" || token != "" || username != ""
+
+	// ConflictsWith only covers the provider block, credentials can also be
+	// set in environment variables.
+	if countSet(kionAPIKey, token, username) > 1 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Unable to create Kion client",
-			Detail:   "Unable to authenticate - " + err.Error(),
+			Summary:  "Conflicting Kion credentials",
+			Detail:   "Only one of apikey, token and username can be set, on the provider or in the KION_APIKEY, KION_TOKEN and KION_USERNAME environment variables.",
+		})
+		return nil, diags
+	}
+
+	// Settings on the provider and in environment variables take precedence
+	// over the Kion CLI configuration file. The file is only read when it is
+	// selected or a required setting is missing.
+	configFile := d.Get("config_file").(string)
+	profileName := d.Get("profile").(string)
+	if kionURL == "" || !hasCredentials || configFile != "" || profileName != "" {
+		path := configFile
+		if path == "" {
+			path = defaultConfigFile
+		}
+		profile, err := loadKionProfile(path, profileName, configFile != "")
+		if err != nil {
+			attr := "config_file"
+			if profileName != "" {
+				attr = "profile"
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Unable to load the Kion configuration file",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath(attr),
+			})
+			return nil, diags
+		}
+		if kionURL == "" {
+			kionURL = profile.URL
+		}
+		if !hasCredentials {
+			kionAPIKey = profile.APIKey
+			if kionAPIKey == "" {
+				username, password = profile.Username, profile.Password
+				if profile.IDMSID != 0 {
+					idmsID = profile.IDMSID
+				}
+			}
+		}
+		if !skipSSLValidationSet(d) && profile.SkipSSLValidation != nil {
+			skipSSLValidation = *profile.SkipSSLValidation
+		}
+	}
+
+	if kionURL == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Missing Kion URL",
+			Detail:        "Set url on the provider, the KION_URL environment variable, or url in the Kion CLI configuration file.",
+			AttributePath: cty.GetAttrPath("url"),
+		})
+	}
+	if kionAPIKey == "" && token == "" && username == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Missing Kion credentials",
+			Detail:        "Set apikey, token, or username and password on the provider, in the KION_APIKEY, KION_TOKEN, or KION_USERNAME and KION_PASSWORD environment variables, or in the Kion CLI configuration file.",
+			AttributePath: cty.GetAttrPath("apikey"),
+		})
+	}
+	if username != "" && password == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Missing Kion password",
+			Detail:        "Set password on the provider or the KION_PASSWORD environment variable to log in as " + username + ".",
+			AttributePath: cty.GetAttrPath("password"),
+		})
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// A pre-issued bearer token is used in place of an API key. With a
+	// username, a session token is obtained by logging in below.
+	if token != "" {
+		kionAPIKey = token
+	}
+
+	client, err := kionclient.NewClient(kionURL, kionAPIKey, kionAPIPath, skipSSLValidation)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Unable to create Kion client",
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath("url"),
 		})
+		return nil, diags
+	}
+	if diags := configureTLS(client, d); diags.HasError() {
+		return nil, diags
+	}
+	if diags := configureProxy(client, d); diags.HasError() {
+		return nil, diags
+	}
+	client.UserAgent = userAgent
+	client.ExtraHeaders = make(map[string]string)
+	for name, value := range d.Get("extra_headers").(map[string]interface{}) {
+		client.ExtraHeaders[name] = value.(string)
+	}
+	client.MaxRetries = d.Get("max_retries").(int)
+	client.RetryMaxWait = time.Duration(d.Get("retry_max_wait").(int)) * time.Second
+	client.SetRateLimit(d.Get("max_requests_per_second").(float64))
+	client.SetMaxConcurrentRequests(d.Get("max_concurrent_requests").(int))
+	client.SetCacheEnabled(!d.Get("disable_cache").(bool))
+	client.ValidateReferences = d.Get("validate_references").(bool)
+
+	if username != "" {
+		client.SetCredentials(idmsID, username, password)
+		if err := client.Login(ctx); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Unable to log in to Kion",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("username"),
+			})
+			return nil, diags
+		}
+	}
 
+	authAttr := "apikey"
+	if username != "" {
+		authAttr = "username"
+	} else if token != "" {
+		authAttr = "token"
+	}
+	diags = append(diags, checkConnection(ctx, client, authAttr)...)
+	if diags.HasError() {
 		return nil, diags
 	}
 
 	return client, diags
 }
+
+// countSet returns the number of values that are not empty.
+func countSet(values ...string) int {
+	n := 0
+	for _, v := range values {
+		if v != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// skipSSLValidationSet reports whether skipsslvalidation is set on the
+// provider or in the environment.
+func skipSSLValidationSet(d *schema.ResourceData) bool {
+	if _, ok := os.LookupEnv("KION_SKIPSSLVALIDATION"); ok {
+		return true
+	}
+	raw := d.GetRawConfig()
+	return raw.IsKnown() && !raw.IsNull() && !raw.GetAttr("skipsslvalidation").IsNull()
+}
+
+// configureTLS applies the custom CA bundle and client certificate settings
+// of the provider to the client.
+func configureTLS(client *kionclient.Client, d *schema.ResourceData) diag.Diagnostics {
+	caCert := []byte(d.Get("ca_cert_pem").(string))
+	if path := d.Get("ca_cert_file").(string); path != "" {
+		var err error
+		if caCert, err = os.ReadFile(path); err != nil {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Unable to read the CA bundle",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("ca_cert_file"),
+			}}
+		}
+	}
+
+	clientCert, err := readPEM(d.Get("client_cert").(string))
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Unable to read the client certificate",
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath("client_cert"),
+		}}
+	}
+	clientKey, err := readPEM(d.Get("client_key").(string))
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Unable to read the client key",
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath("client_key"),
+		}}
+	}
+
+	if err := client.SetTLSConfig(caCert, clientCert, clientKey); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Invalid TLS configuration",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
+
+// configureProxy routes requests to Kion through the proxy configured on
+// the provider. Without a proxy_url the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+// environment variables apply.
+func configureProxy(client *kionclient.Client, d *schema.ResourceData) diag.Diagnostics {
+	proxyURL := d.Get("proxy_url").(string)
+	if proxyURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid proxy URL",
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath("proxy_url"),
+		}}
+	}
+	if username := d.Get("proxy_username").(string); username != "" {
+		u.User = url.UserPassword(username, d.Get("proxy_password").(string))
+	}
+
+	if err := client.SetProxy(u, d.Get("no_proxy").(string)); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid proxy configuration",
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath("proxy_url"),
+		}}
+	}
+
+	return nil
+}
+
+// readPEM returns value if it holds PEM encoded data, otherwise it treats
+// value as the path of a file to read.
+func readPEM(value string) ([]byte, error) {
+	if value == "" || strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+// authErrorDetails explains a rejected request for each provider attribute
+// that configures authentication.
+var authErrorDetails = map[string]string{
+	"apikey":   "Kion rejected the API key. Verify the apikey attribute, the KION_APIKEY environment variable or the Kion CLI configuration file, and that the key has not expired.",
+	"token":    "Kion rejected the bearer token. Verify the token attribute or the KION_TOKEN environment variable and that the token has not expired.",
+	"username": "Kion rejected the session obtained by logging in with username and password. Verify that the user is allowed to use the API.",
+}
+
+// checkConnection verifies the credentials by looking up the user they belong
+// to, detects the version of the Kion installation and checks that the user
+// can read the OU hierarchy that most resources and data sources depend on.
+// authAttr is the attribute that configured the credentials: apikey, token or
+// username.
+func checkConnection(ctx context.Context, client *kionclient.Client, authAttr string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	me := new(kionclient.MeResponse)
+	err := client.GETContext(ctx, "/v3/me", me)
+	if kionclient.IsNotFound(err) {
+		// Older Kion versions don't expose the current user, fall back to an
+		// endpoint every authenticated user can read.
+		err = client.GETContext(ctx, "/v3/me/cloud-access-role", nil)
+	}
+	switch {
+	case kionclient.IsUnauthorized(err):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Unable to authenticate with Kion",
+			Detail:        authErrorDetails[authAttr],
+			AttributePath: cty.GetAttrPath(authAttr),
+		}}
+	case kionclient.IsForbidden(err):
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Insufficient Kion permissions",
+			Detail:   "The credentials are valid but are not allowed to read their own user. Ensure the user has a permission scheme that grants API access.",
+		}}
+	case err != nil:
+		return apiErrorDiagnostics(nil, "Unable to connect to Kion", err)
+	}
+
+	identity := "the user of the credentials"
+	if me.Data.Username != "" {
+		identity = fmt.Sprintf("%s (ID %d)", me.Data.Username, me.Data.ID)
+	}
+
+	connected := fmt.Sprintf("Connected to %s as %s", client.HostURL, identity)
+	version, err := client.DetectVersion(ctx)
+	if err == nil {
+		connected += fmt.Sprintf(" running Kion %s", version)
+	} else {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unable to determine the Kion version",
+			Detail:   fmt.Sprintf("Features that require a minimum Kion version are not checked before they are used: %v", err),
+		})
+	}
+
+	// The OU list is cached, so data sources reading it later in the run
+	// don't send the request again.
+	err = client.GETAllContext(ctx, "/v3/ou", new(kionclient.OUListResponse))
+	switch {
+	case kionclient.IsForbidden(err):
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Insufficient Kion permissions",
+			Detail:   connected + ". The user is not allowed to list OUs, so resources and data sources that read OUs will fail. Ensure the user has a permission scheme that grants read access to the OUs Terraform manages.",
+		})
+	case err != nil:
+		return append(diags, apiErrorDiagnostics(nil, "Unable to connect to Kion", err)...)
+	}
+
+	tflog.Info(ctx, "Connected to Kion", map[string]interface{}{
+		"url":          client.HostURL,
+		"user_id":      me.Data.ID,
+		"username":     me.Data.Username,
+		"kion_version": version.String(),
+	})
+
+	return diags
+}
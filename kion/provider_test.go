@@ -1,10 +1,21 @@
 package kion
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+	"github.com/stretchr/testify/assert"
 )
 
 var testAccProviders map[string]*schema.Provider
@@ -17,16 +28,188 @@ func init() {
 	}
 }
 
+// newTestClient starts a test server that serves handler and returns a client
+// for it that doesn't retry. The server is closed when the test ends.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *hc.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := hc.NewClient(server.URL, "app_1_test", "/api", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.MaxRetries = 0
+	return client
+}
+
+// testCreate creates r from the configuration in raw and fails the test when
+// the create returns errors.
+func testCreate(t *testing.T, r *schema.Resource, raw map[string]interface{}, client *hc.Client) *schema.ResourceData {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unable to create: %v", diags)
+	}
+	return d
+}
+
+// readTestBody decodes the JSON body of a request to a test server into v and
+// returns the raw body.
+func readTestBody(t *testing.T, r *http.Request, v interface{}) string {
+	t.Helper()
+	b, err := io.ReadAll(r.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(b, v))
+	return string(b)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
 	}
 }
 
+// TestProviderDocumented checks that every documented resource and data
+// source is registered with the provider.
+func TestProviderDocumented(t *testing.T) {
+	p := Provider()
+	for dir, registered := range map[string]map[string]*schema.Resource{
+		"../docs/resources":    p.ResourcesMap,
+		"../docs/data-sources": p.DataSourcesMap,
+	} {
+		files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, files, dir)
+
+		for _, file := range files {
+			name := "kion_" + strings.TrimSuffix(filepath.Base(file), ".md")
+			_, ok := registered[name]
+			assert.True(t, ok, "%s is documented in %s but not registered", name, file)
+		}
+	}
+}
+
 func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProviderURLValidation(t *testing.T) {
+	validate := Provider().Schema["url"].ValidateFunc
+
+	_, errs := validate("https://kion.example.com", "url")
+	assert.Empty(t, errs)
+
+	_, errs = validate("kion.example.com", "url")
+	assert.NotEmpty(t, errs)
+
+	validate = Provider().Schema["apipath"].ValidateFunc
+
+	_, errs = validate("/api", "apipath")
+	assert.Empty(t, errs)
+
+	_, errs = validate("api", "apipath")
+	assert.NotEmpty(t, errs)
+}
+
+func TestCheckConnection(t *testing.T) {
+	status := http.StatusOK
+	ouStatus := http.StatusOK
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/me":
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"status": 200, "data": {"id": 1, "username": "admin"}}`))
+		case "/api/version":
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"status": 200, "data": "3.9.0"}`))
+		case "/api/v3/ou":
+			w.WriteHeader(ouStatus)
+			_, _ = w.Write([]byte(`{"status": 200, "data": []}`))
+		default:
+			w.WriteHeader(status)
+		}
+	})
+	client.SetCacheEnabled(false)
+
+	diags := checkConnection(context.Background(), client, "apikey")
+	assert.Empty(t, diags)
+
+	// A user that can't list OUs is warned about, naming the user and the
+	// Kion version.
+	ouStatus = http.StatusForbidden
+	diags = checkConnection(context.Background(), client, "apikey")
+	assert.False(t, diags.HasError())
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, "admin (ID 1)")
+	assert.Contains(t, diags[0].Detail, "Kion 3.9.0")
+	ouStatus = http.StatusOK
+
+	// The error names the attribute that configured the credentials.
+	status = http.StatusUnauthorized
+	diags = checkConnection(context.Background(), client, "apikey")
+	assert.True(t, diags.HasError())
+	assert.Equal(t, cty.GetAttrPath("apikey"), diags[0].AttributePath)
+	assert.Contains(t, diags[0].Detail, "API key")
+
+	diags = checkConnection(context.Background(), client, "token")
+	assert.True(t, diags.HasError())
+	assert.Equal(t, cty.GetAttrPath("token"), diags[0].AttributePath)
+	assert.Contains(t, diags[0].Detail, "bearer token")
+
+	diags = checkConnection(context.Background(), client, "username")
+	assert.True(t, diags.HasError())
+	assert.Equal(t, cty.GetAttrPath("username"), diags[0].AttributePath)
+	assert.NotContains(t, diags[0].Detail, "API key")
+
+	status = http.StatusForbidden
+	diags = checkConnection(context.Background(), client, "apikey")
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Insufficient Kion permissions", diags[0].Summary)
+}
+
+func TestProviderConfigureConfigFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/me":
+			_, _ = w.Write([]byte(`{"status": 200, "data": {"id": 1, "username": "admin"}}`))
+		case "/api/version":
+			_, _ = w.Write([]byte(`{"status": 200, "data": "3.9.0"}`))
+		case "/api/v3/ou":
+			_, _ = w.Write([]byte(`{"status": 200, "data": []}`))
+		}
+	}))
+	defer server.Close()
+
+	// An unreadable default configuration file is ignored when the url and
+	// credentials are set on the provider.
+	home := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(home, ".kion.yml"), []byte("kion: ["), 0o600))
+	t.Setenv("HOME", home)
+
+	raw := map[string]interface{}{"url": server.URL, "apikey": "app_1_test", "max_retries": 0}
+	d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+	_, diags := providerConfigure(context.Background(), d, "test")
+	assert.False(t, diags.HasError(), diags)
+
+	raw["profile"] = "staging"
+	d = schema.TestResourceDataRaw(t, Provider().Schema, raw)
+	_, diags = providerConfigure(context.Background(), d, "test")
+	assert.True(t, diags.HasError())
+	assert.Equal(t, cty.GetAttrPath("profile"), diags[0].AttributePath)
+}
+
+func TestProviderConfigureConflictingCredentials(t *testing.T) {
+	t.Setenv("KION_TOKEN", "session")
+
+	raw := map[string]interface{}{"url": "https://kion.example.com", "apikey": "app_1_test"}
+	d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+	_, diags := providerConfigure(context.Background(), d, "test")
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Conflicting Kion credentials", diags[0].Summary)
+}
+
 func testAccPreCheck(t *testing.T) {
 	if err := os.Getenv("KION_URL"); err == "" {
 		t.Fatal("KION_URL must be set for acceptance tests")
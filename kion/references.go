@@ -0,0 +1,142 @@
+package kion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+// referenceNames are the names used in validation errors for the collections
+// that can be referenced.
+var referenceNames = map[string]string{
+	hc.ReferenceCloudRule:        "cloud rule",
+	hc.ReferenceFundingSource:    "funding source",
+	hc.ReferenceOU:               "OU",
+	hc.ReferencePayer:            "payer",
+	hc.ReferencePermissionScheme: "permission scheme",
+	hc.ReferenceProject:          "project",
+	hc.ReferenceUser:             "user",
+	hc.ReferenceUserGroup:        "user group",
+}
+
+// reference is an attribute that holds the IDs of objects in a Kion
+// collection. field is the path to the IDs inside nested blocks, e.g. id for
+// owner_users or data.funding_source_id for budget.
+type reference struct {
+	attribute  string
+	field      string
+	collection string
+	labelKeys  bool
+}
+
+// referenceTo references collection from an attribute holding an ID, or from
+// a field of a nested block.
+func referenceTo(attribute, field, collection string) reference {
+	return reference{attribute: attribute, field: field, collection: collection}
+}
+
+// labelReference references the label keys of a labels map.
+func labelReference(attribute string) reference {
+	return reference{attribute: attribute, labelKeys: true}
+}
+
+// validateReferences fails the plan when a referenced object does not exist
+// in Kion. It only runs when the validate_references provider attribute is
+// set, and only checks values that changed and are known at plan time. All
+// missing references are reported at once.
+func validateReferences(refs ...reference) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		client, ok := m.(*hc.Client)
+		if !ok || !client.ValidateReferences {
+			return nil
+		}
+
+		var errs []error
+		for _, ref := range refs {
+			if d.Id() != "" && !d.HasChange(ref.attribute) {
+				continue
+			}
+			if !d.NewValueKnown(ref.attribute) {
+				continue
+			}
+			errs = append(errs, ref.validate(ctx, client, d.Get(ref.attribute))...)
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+func (ref reference) validate(ctx context.Context, client *hc.Client, v interface{}) []error {
+	var errs []error
+
+	if ref.labelKeys {
+		labels, _ := v.(map[string]interface{})
+		keys := make([]string, 0, len(labels))
+		for key := range labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			exists, err := client.LabelKeyExists(ctx, key)
+			if err != nil {
+				return append(errs, fmt.Errorf("unable to validate %s: %w", ref.attribute, err))
+			}
+			if !exists {
+				errs = append(errs, fmt.Errorf("%s: label key %q does not exist in Kion", ref.attribute, key))
+			}
+		}
+		return errs
+	}
+
+	var keys []string
+	if ref.field != "" {
+		keys = strings.Split(ref.field, ".")
+	}
+	ids := make(map[int]bool)
+	collectIDs(v, keys, ids)
+
+	sorted := make([]int, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Ints(sorted)
+
+	for _, id := range sorted {
+		exists, err := client.ReferenceExists(ctx, ref.collection, id)
+		if err != nil {
+			return append(errs, fmt.Errorf("unable to validate %s: %w", ref.attribute, err))
+		}
+		if !exists {
+			errs = append(errs, fmt.Errorf("%s: %s %d does not exist in Kion", ref.attribute, referenceNames[ref.collection], id))
+		}
+	}
+
+	return errs
+}
+
+// collectIDs adds the non-zero IDs found at keys inside v to ids. Zero is the
+// value of IDs that are unset or unknown at plan time.
+func collectIDs(v interface{}, keys []string, ids map[int]bool) {
+	switch x := v.(type) {
+	case int:
+		if x != 0 {
+			ids[x] = true
+		}
+	case *schema.Set:
+		collectIDs(x.List(), keys, ids)
+	case []interface{}:
+		for _, item := range x {
+			collectIDs(item, keys, ids)
+		}
+	case map[string]interface{}:
+		if len(keys) > 0 {
+			collectIDs(x[keys[0]], keys[1:], ids)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package kion
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectIDs(t *testing.T) {
+	budget := []interface{}{
+		map[string]interface{}{
+			"data": schema.NewSet(schema.HashResource(&schema.Resource{Schema: map[string]*schema.Schema{
+				"funding_source_id": {Type: schema.TypeInt},
+			}}), []interface{}{
+				map[string]interface{}{"funding_source_id": 3},
+				map[string]interface{}{"funding_source_id": 0},
+			}),
+			"funding_source_ids": schema.NewSet(schema.HashInt, []interface{}{4, 5}),
+		},
+	}
+
+	ids := make(map[int]bool)
+	collectIDs(budget, []string{"data", "funding_source_id"}, ids)
+	assert.Equal(t, map[int]bool{3: true}, ids)
+
+	ids = make(map[int]bool)
+	collectIDs(budget, []string{"funding_source_ids"}, ids)
+	assert.Equal(t, map[int]bool{4: true, 5: true}, ids)
+
+	ids = make(map[int]bool)
+	collectIDs(7, nil, ids)
+	assert.Equal(t, map[int]bool{7: true}, ids)
+}
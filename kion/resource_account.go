@@ -21,8 +21,6 @@ import (
 //   kion/resource_azure_subscription_account.go
 
 func resourceAccountRead(resource string, ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Acknowledge the context parameter to avoid linter errors
-	_ = ctx
 	var diags diag.Diagnostics
 	client := m.(*hc.Client)
 	ID := d.Id()
@@ -64,24 +62,21 @@ func resourceAccountRead(resource string, ctx context.Context, d *schema.Resourc
 		accountUrl = fmt.Sprintf("/v3/account/%s", ID)
 		resp = new(hc.AccountResponse)
 	}
-	err := client.GET(accountUrl, resp)
+	err := client.GETContext(ctx, accountUrl, resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read account",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "account not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read account", err)...)
 		return diags
 	}
 
 	if locationChanged {
 		d.SetId(ID)
 		if err := d.Set("location", accountLocation); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to set location for account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to set location for account", err)...)
 			return diags
 		}
 	}
@@ -89,35 +84,23 @@ func resourceAccountRead(resource string, ctx context.Context, d *schema.Resourc
 	data := resp.ToMap(resource)
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set account", err)...)
 			return diags
 		}
 	}
 
 	// Fetch labels
 	if accountLocation == ProjectLocation {
-		labelData, err := hc.ReadResourceLabels(client, "account", ID)
+		labelData, err := hc.ReadResourceLabels(ctx, client, "account", ID)
 
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read account labels",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read account labels", err)...)
 			return diags
 		}
 
 		// Set labels
 		if err := d.Set("labels", labelData); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to set labels for account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to set labels for account", err)...)
 		}
 	}
 
@@ -143,33 +126,21 @@ func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		// Handle conversion from cache account to project account
 		accountCacheId, err := strconv.Atoi(ID)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to convert cached account to project account, invalid cached account id",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to convert cached account to project account, invalid cached account id", err)...)
 			return diags
 		}
 
 		tflog.Debug(ctx, "Converting from cached account to project account", map[string]interface{}{"oldProjectId": oldProjectId, "newProjectId": newProjectId})
-		newId, err := convertCacheAccountToProjectAccount(client, accountCacheId, newProjectId, d.Get("start_datecode").(string))
+		newId, err := convertCacheAccountToProjectAccount(ctx, client, accountCacheId, newProjectId, d.Get("start_datecode").(string))
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to convert cached account to project account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to convert cached account to project account", err)...)
 			return diags
 		}
 
 		accountLocation = ProjectLocation
 		ID = strconv.Itoa(newId)
 		if err := d.Set("location", accountLocation); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Error setting location",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), accountLocation),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Error setting location", err)...)
 			return diags
 		}
 		d.SetId(ID)
@@ -178,22 +149,14 @@ func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		// Handle conversion from project account to cache account
 		accountId, err := strconv.Atoi(ID)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to convert project account to cache account, invalid account id",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to convert project account to cache account, invalid account id", err)...)
 			return diags
 		}
 
 		tflog.Debug(ctx, "Converting from project account to cached account", map[string]interface{}{"oldProjectId": oldProjectId, "newProjectId": newProjectId})
-		newId, err := convertProjectAccountToCacheAccount(client, accountId)
+		newId, err := convertProjectAccountToCacheAccount(ctx, client, accountId)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to convert project account to cache account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to convert project account to cache account", err)...)
 			return diags
 		}
 
@@ -201,11 +164,7 @@ func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		ID = strconv.Itoa(newId)
 
 		if err := d.Set("location", accountLocation); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to set location",
-				Detail:   fmt.Sprintf("Error: %v", err),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to set location", err)...)
 			return diags
 		}
 
@@ -237,13 +196,9 @@ func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interf
 				tflog.Debug(ctx, "Moving account to different project", map[string]interface{}{"oldProjectId": oldProjectId, "newProjectId": newProjectId, "postData": string(rb)})
 			}
 
-			resp, err := client.POST(fmt.Sprintf("/v3/account/%s/move", ID), req)
+			resp, err := client.POSTContext(ctx, fmt.Sprintf("/v3/account/%s/move", ID), req)
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to move account to a different project",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to move account to a different project", err)...)
 				return diags
 			}
 
@@ -301,13 +256,9 @@ func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			tflog.Debug(ctx, fmt.Sprintf("Updating account via PATCH %s", accountUrl), map[string]interface{}{"postData": string(rb)})
 		}
 
-		err := client.PATCH(accountUrl, req)
+		err := client.PATCHContext(ctx, accountUrl, req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update account", err)...)
 			return diags
 		}
 	}
@@ -315,25 +266,17 @@ func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	if accountLocation == ProjectLocation && d.HasChanges("labels") {
 		hasChanged = true
 
-		err := hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "account", ID)
+		err := hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "account", ID)
 
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update account labels",
-				Detail:   fmt.Sprintf("Error: %v\nAccount ID: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update account labels", err)...)
 			return diags
 		}
 	}
 
 	if hasChanged {
 		if err := d.Set("last_updated", time.Now().Format(time.RFC850)); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to set last_updated",
-				Detail:   fmt.Sprintf("Error: %v", err),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to set last_updated", err)...)
 			return diags
 		}
 		tflog.Info(ctx, fmt.Sprintf("Updated account ID: %s", ID))
@@ -343,9 +286,6 @@ func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Acknowledge the context parameter to avoid linter errors
-	_ = ctx
-
 	var diags diag.Diagnostics
 	client := m.(*hc.Client)
 	ID := d.Id()
@@ -362,13 +302,9 @@ func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interf
 		accountUrl = fmt.Sprintf("/v3/account/%s", ID)
 	}
 
-	err := client.DELETE(accountUrl, nil)
+	err := client.DELETEContext(ctx, accountUrl, nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete account",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete account", err)...)
 		return diags
 	}
 
@@ -377,12 +313,12 @@ func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interf
 	return diags
 }
 
-func convertCacheAccountToProjectAccount(client *hc.Client, accountCacheId, newProjectId int, startDatecode string) (int, error) {
+func convertCacheAccountToProjectAccount(ctx context.Context, client *hc.Client, accountCacheId, newProjectId int, startDatecode string) (int, error) {
 
 	// The API is inconsistent and convert expects YYYYMM while other methods expect YYYY-MM
 	startDatecode = strings.ReplaceAll(startDatecode, "-", "")
 
-	resp, err := client.POST(fmt.Sprintf("/v3/account-cache/%d/convert/%d?start_datecode=%s",
+	resp, err := client.POSTContext(ctx, fmt.Sprintf("/v3/account-cache/%d/convert/%d?start_datecode=%s",
 		accountCacheId, newProjectId, startDatecode), nil)
 
 	if err != nil {
@@ -392,9 +328,9 @@ func convertCacheAccountToProjectAccount(client *hc.Client, accountCacheId, newP
 	return resp.RecordID, nil
 }
 
-func convertProjectAccountToCacheAccount(client *hc.Client, accountId int) (int, error) {
+func convertProjectAccountToCacheAccount(ctx context.Context, client *hc.Client, accountId int) (int, error) {
 	respRevert := new(hc.AccountRevertResponse)
-	err := client.DeleteWithResponse(fmt.Sprintf("/v3/account/revert/%d", accountId), nil, respRevert)
+	err := client.DeleteWithResponseContext(ctx, fmt.Sprintf("/v3/account/revert/%d", accountId), nil, respRevert)
 
 	if err != nil {
 		return 0, err
@@ -3,10 +3,8 @@ package kion
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -40,8 +38,7 @@ func resourceAwsAccount() *schema.Resource {
 		DeleteContext: resourceAwsAccountDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceAwsAccountRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceAwsAccountRead)
 			},
 		},
 		Schema: map[string]*schema.Schema{
@@ -203,6 +200,12 @@ func resourceAwsAccount() *schema.Resource {
 			// schema validators don't support multi-attribute validations, so we use CustomizeDiff instead
 			validateAwsAccountStartDatecode,
 			customDiffComputedAccountLocation,
+			requireKionVersion("kion_aws_account", hc.VersionAccounts),
+			validateReferences(
+				referenceTo("payer_id", "", hc.ReferencePayer),
+				referenceTo("project_id", "", hc.ReferenceProject),
+				labelReference("labels"),
+			),
 		),
 	}
 }
@@ -263,19 +266,15 @@ func resourceAwsAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 		if rb, err := json.Marshal(postAccountData); err == nil {
 			tflog.Debug(ctx, fmt.Sprintf("Importing exiting AWS account via POST %s", accountUrl), map[string]interface{}{"postData": string(rb)})
 		}
-		resp, err := client.POST(accountUrl, postAccountData)
+		resp, err := client.POSTContext(ctx, accountUrl, postAccountData)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to import AWS Account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), postAccountData),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to import AWS Account", err)...)
 			return diags
 		} else if resp.RecordID == 0 {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "Unable to import AWS Account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), postAccountData),
+				Detail:   "Kion returned an item ID of 0.",
 			})
 			return diags
 		}
@@ -303,16 +302,10 @@ func resourceAwsAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 			// Move cached account to the requested project
 			projectId := d.Get("project_id").(int)
 			startDatecode := time.Now().Format("200601")
-			retries := 3              // Number of retries
-			delay := 30 * time.Second // Delay between retries
 
-			newId, err := retryConvertCacheAccountToProjectAccountForAWS(client, accountCacheId, projectId, startDatecode, retries, delay)
+			newId, err := convertCacheAccountToProjectAccount(ctx, client, accountCacheId, projectId, startDatecode)
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to convert AWS cached account to project account",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), accountCacheId),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to convert AWS cached account to project account", err)...)
 				diags = append(diags, resourceAwsAccountRead(ctx, d, m)...)
 				return diags
 			}
@@ -347,14 +340,10 @@ func resourceAwsAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 	if accountLocation == ProjectLocation {
 		if _, ok := d.GetOk("labels"); ok {
 			ID := d.Id()
-			err := hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "account", ID)
+			err := hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "account", ID)
 
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to update AWS account labels",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to update AWS account labels", err)...)
 				diags = append(diags, resourceAwsAccountRead(ctx, d, m)...)
 				return diags
 			}
@@ -394,12 +383,12 @@ func createAwsAccount(ctx context.Context, client *hc.Client, d *schema.Resource
 	}
 
 	// Send the POST request to create the AWS account.
-	respCache, err := client.POST("/v3/account-cache/create?account-type=aws", postCacheData)
+	respCache, err := client.POSTContext(ctx, "/v3/account-cache/create?account-type=aws", postCacheData)
 	if err != nil || respCache.RecordID == 0 {
 		if err == nil {
 			err = fmt.Errorf("received item ID of 0")
 		}
-		return diag.Errorf("Unable to create AWS Account: %v", err), 0
+		return apiErrorDiagnostics(d, "Unable to create AWS Account", err), 0
 	}
 
 	// Wait for the account to be fully created.
@@ -449,7 +438,7 @@ func waitForAccountCreation(client *hc.Client, ctx context.Context, accountCache
 		// Define the refresh function, which checks the account creation status.
 		Refresh: func() (interface{}, string, error) {
 			resp := new(hc.AccountResponse)
-			err := client.GET(fmt.Sprintf("/v3/account-cache/%d", accountCacheId), resp)
+			err := client.GETContext(ctx, fmt.Sprintf("/v3/account-cache/%d", accountCacheId), resp)
 			if err != nil {
 				// Directly return errors, including NotFound, allowing the SDK to handle retries for NotFound appropriately.
 				tflog.Trace(ctx, fmt.Sprintf("Checking new AWS account status: /v3/account-cache/%d error", accountCacheId), map[string]interface{}{"error": err, "accountCacheId": accountCacheId})
@@ -475,23 +464,6 @@ func waitForAccountCreation(client *hc.Client, ctx context.Context, accountCache
 	return err // Return the error, if any.
 }
 
-func retryConvertCacheAccountToProjectAccountForAWS(client *hc.Client, accountCacheId, projectId int, startDatecode string, retries int, delay time.Duration) (int, error) {
-	var lastErr error
-	for i := 0; i < retries; i++ {
-		id, err := convertCacheAccountToProjectAccount(client, accountCacheId, projectId, startDatecode)
-		if err == nil {
-			return id, nil
-		}
-		if strings.Contains(err.Error(), "Rule is already in progress") && i < retries-1 {
-			time.Sleep(delay)
-			continue
-		}
-		lastErr = err
-		break
-	}
-	return 0, lastErr
-}
-
 func resourceAwsAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return resourceAccountRead("kion_aws_account", ctx, d, m)
 }
@@ -2,28 +2,35 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 func resourceAwsCloudformationTemplate() *schema.Resource {
-	return &schema.Resource{
+	return withOwnerNames("owner_users", "owner_user_groups", &schema.Resource{
 		CreateContext: resourceAwsCloudformationTemplateCreate,
 		ReadContext:   resourceAwsCloudformationTemplateRead,
 		UpdateContext: resourceAwsCloudformationTemplateUpdate,
 		DeleteContext: resourceAwsCloudformationTemplateDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceAwsCloudformationTemplateRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceAwsCloudformationTemplateRead)
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			requireKionVersionForAttribute("kion_aws_cloudformation_template", "tags", hc.VersionCFTTags),
+			validateReferences(
+				referenceTo("owner_users", "id", hc.ReferenceUser),
+				referenceTo("owner_user_groups", "id", hc.ReferenceUserGroup),
+			),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -99,7 +106,7 @@ func resourceAwsCloudformationTemplate() *schema.Resource {
 				Description: "Stack-level tags will apply to all supported resources in a CloudFormation stack.  Requires Kion >= 3.7.1.",
 			},
 		},
-	}
+	})
 }
 
 func resourceAwsCloudformationTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -120,19 +127,15 @@ func resourceAwsCloudformationTemplateCreate(ctx context.Context, d *schema.Reso
 		TerminationProtection: d.Get("termination_protection").(bool),
 	}
 
-	resp, err := client.POST("/v3/cft", post)
+	resp, err := client.POSTContext(ctx, "/v3/cft", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create AwsCloudformationTemplate",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create AwsCloudformationTemplate", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create AwsCloudformationTemplate",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -150,13 +153,14 @@ func resourceAwsCloudformationTemplateRead(ctx context.Context, d *schema.Resour
 	ID := d.Id()
 
 	resp := new(hc.CFTResponseWithOwnersAndTags)
-	err := client.GET(fmt.Sprintf("/v3/cft/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/cft/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read AwsCloudformationTemplate",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "AwsCloudformationTemplate not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read AwsCloudformationTemplate", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -180,11 +184,7 @@ func resourceAwsCloudformationTemplateRead(ctx context.Context, d *schema.Resour
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set AwsCloudformationTemplate",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set AwsCloudformationTemplate", err)...)
 			return diags
 		}
 	}
@@ -225,13 +225,9 @@ func resourceAwsCloudformationTemplateUpdate(ctx context.Context, d *schema.Reso
 			TerminationProtection: d.Get("termination_protection").(bool),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/cft/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/cft/%s", ID), req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update AwsCloudformationTemplate",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update AwsCloudformationTemplate", err)...)
 			return diags
 		}
 	}
@@ -245,32 +241,24 @@ func resourceAwsCloudformationTemplateUpdate(ctx context.Context, d *schema.Reso
 
 		if len(arrAddOwnerUserGroupIds) > 0 ||
 			len(arrAddOwnerUserIds) > 0 {
-			_, err := client.POST(fmt.Sprintf("/v3/cft/%s/owner", ID), hc.ChangeOwners{
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/cft/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrAddOwnerUserGroupIds,
 				OwnerUserIds:      &arrAddOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add owners on AwsCloudformationTemplate",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add owners on AwsCloudformationTemplate", err)...)
 				return diags
 			}
 		}
 
 		if len(arrRemoveOwnerUserGroupIds) > 0 ||
 			len(arrRemoveOwnerUserIds) > 0 {
-			err := client.DELETE(fmt.Sprintf("/v3/cft/%s/owner", ID), hc.ChangeOwners{
+			err := client.DELETEContext(ctx, fmt.Sprintf("/v3/cft/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrRemoveOwnerUserGroupIds,
 				OwnerUserIds:      &arrRemoveOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove owners on AwsCloudformationTemplate",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove owners on AwsCloudformationTemplate", err)...)
 				return diags
 			}
 		}
@@ -294,13 +282,9 @@ func resourceAwsCloudformationTemplateDelete(ctx context.Context, d *schema.Reso
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/cft/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/cft/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete AwsCloudformationTemplate",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete AwsCloudformationTemplate", err)...)
 		return diags
 	}
 
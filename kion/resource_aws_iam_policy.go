@@ -2,28 +2,31 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 func resourceAwsIamPolicy() *schema.Resource {
-	return &schema.Resource{
+	return withOwnerNames("owner_users", "owner_user_groups", &schema.Resource{
 		CreateContext: resourceAwsIamPolicyCreate,
 		ReadContext:   resourceAwsIamPolicyRead,
 		UpdateContext: resourceAwsIamPolicyUpdate,
 		DeleteContext: resourceAwsIamPolicyDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceAwsIamPolicyRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceAwsIamPolicyRead)
 			},
 		},
+		CustomizeDiff: validateReferences(
+			referenceTo("owner_users", "id", hc.ReferenceUser),
+			referenceTo("owner_user_groups", "id", hc.ReferenceUserGroup),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -89,7 +92,7 @@ func resourceAwsIamPolicy() *schema.Resource {
 				Computed: true,
 			},
 		},
-	}
+	})
 }
 
 func resourceAwsIamPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -105,19 +108,15 @@ func resourceAwsIamPolicyCreate(ctx context.Context, d *schema.ResourceData, m i
 		Policy:            d.Get("policy").(string),
 	}
 
-	resp, err := client.POST("/v3/iam-policy", post)
+	resp, err := client.POSTContext(ctx, "/v3/iam-policy", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create AwsIamPolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create AwsIamPolicy", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create AwsIamPolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -135,13 +134,14 @@ func resourceAwsIamPolicyRead(ctx context.Context, d *schema.ResourceData, m int
 	ID := d.Id()
 
 	resp := new(hc.IAMPolicyResponse)
-	err := client.GET(fmt.Sprintf("/v3/iam-policy/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/iam-policy/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read AwsIamPolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "AwsIamPolicy not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read AwsIamPolicy", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -163,11 +163,7 @@ func resourceAwsIamPolicyRead(ctx context.Context, d *schema.ResourceData, m int
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set AwsIamPolicy",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set AwsIamPolicy", err)...)
 			return diags
 		}
 	}
@@ -196,13 +192,9 @@ func resourceAwsIamPolicyUpdate(ctx context.Context, d *schema.ResourceData, m i
 			Policy:      d.Get("policy").(string),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/iam-policy/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/iam-policy/%s", ID), req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update AwsIamPolicy",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update AwsIamPolicy", err)...)
 			return diags
 		}
 	}
@@ -216,32 +208,24 @@ func resourceAwsIamPolicyUpdate(ctx context.Context, d *schema.ResourceData, m i
 
 		if len(arrAddOwnerUserGroupIds) > 0 ||
 			len(arrAddOwnerUserIds) > 0 {
-			_, err := client.POST(fmt.Sprintf("/v3/iam-policy/%s/owner", ID), hc.ChangeOwners{
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/iam-policy/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrAddOwnerUserGroupIds,
 				OwnerUserIds:      &arrAddOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add owners on AwsIamPolicy",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add owners on AwsIamPolicy", err)...)
 				return diags
 			}
 		}
 
 		if len(arrRemoveOwnerUserGroupIds) > 0 ||
 			len(arrRemoveOwnerUserIds) > 0 {
-			err := client.DELETE(fmt.Sprintf("/v3/iam-policy/%s/owner", ID), hc.ChangeOwners{
+			err := client.DELETEContext(ctx, fmt.Sprintf("/v3/iam-policy/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrRemoveOwnerUserGroupIds,
 				OwnerUserIds:      &arrRemoveOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove owners on AwsIamPolicy",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove owners on AwsIamPolicy", err)...)
 				return diags
 			}
 		}
@@ -266,13 +250,9 @@ func resourceAwsIamPolicyDelete(ctx context.Context, d *schema.ResourceData, m i
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/iam-policy/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/iam-policy/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete AwsIamPolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete AwsIamPolicy", err)...)
 		return diags
 	}
 
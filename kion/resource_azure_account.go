@@ -3,7 +3,6 @@ package kion
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -40,8 +39,7 @@ func resourceAzureAccount() *schema.Resource {
 		DeleteContext: resourceAzureAccountDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceAzureAccountRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceAzureAccountRead)
 			},
 		},
 		Schema: map[string]*schema.Schema{
@@ -207,6 +205,12 @@ func resourceAzureAccount() *schema.Resource {
 			// schema validators don't support multi-attribute validations, so we use CustomizeDiff instead
 			validateAzureAccountStartDatecode,
 			customDiffComputedAccountLocation,
+			requireKionVersion("kion_azure_account", hc.VersionAccounts),
+			validateReferences(
+				referenceTo("payer_id", "", hc.ReferencePayer),
+				referenceTo("project_id", "", hc.ReferenceProject),
+				labelReference("labels"),
+			),
 		),
 	}
 }
@@ -251,19 +255,15 @@ func resourceAzureAccountCreate(ctx context.Context, d *schema.ResourceData, m i
 		if rb, err := json.Marshal(postAccountData); err == nil {
 			tflog.Debug(ctx, fmt.Sprintf("Importing exiting Azure account via POST %s", accountUrl), map[string]interface{}{"postData": string(rb)})
 		}
-		resp, err := client.POST(accountUrl, postAccountData)
+		resp, err := client.POSTContext(ctx, accountUrl, postAccountData)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to import Azure Account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), postAccountData),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to import Azure Account", err)...)
 			return diags
 		} else if resp.RecordID == 0 {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "Unable to import Azure Account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), postAccountData),
+				Detail:   "Kion returned an item ID of 0.",
 			})
 			return diags
 		}
@@ -328,19 +328,15 @@ func resourceAzureAccountCreate(ctx context.Context, d *schema.ResourceData, m i
 		if rb, err := json.Marshal(postCacheData); err == nil {
 			tflog.Debug(ctx, "Creating new Azure account via POST /v3/account-cache/create?account-type=azure", map[string]interface{}{"postData": string(rb)})
 		}
-		respCache, err := client.POST("/v3/account-cache/create?account-type=azure", postCacheData)
+		respCache, err := client.POSTContext(ctx, "/v3/account-cache/create?account-type=azure", postCacheData)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to create Azure Account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), postCacheData),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to create Azure Account", err)...)
 			return diags
 		} else if respCache.RecordID == 0 {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "Unable to create Azure Account",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), postCacheData),
+				Detail:   "Kion returned an item ID of 0.",
 			})
 			return diags
 		}
@@ -351,7 +347,7 @@ func resourceAzureAccountCreate(ctx context.Context, d *schema.ResourceData, m i
 		createStateConf := &retry.StateChangeConf{
 			Refresh: func() (interface{}, string, error) {
 				resp := new(hc.AccountResponse)
-				err := client.GET(fmt.Sprintf("/v3/account-cache/%d", accountCacheId), resp)
+				err := client.GETContext(ctx, fmt.Sprintf("/v3/account-cache/%d", accountCacheId), resp)
 				if err != nil {
 					if resErr, ok := err.(*hc.RequestError); ok {
 						if resErr.StatusCode == http.StatusNotFound {
@@ -379,11 +375,7 @@ func resourceAzureAccountCreate(ctx context.Context, d *schema.ResourceData, m i
 		}
 		_, err = createStateConf.WaitForStateContext(ctx)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to create Azure Account",
-				Detail:   fmt.Sprintf("Error: %v", err.Error()),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to create Azure Account", err)...)
 			return diags
 		}
 
@@ -393,13 +385,9 @@ func resourceAzureAccountCreate(ctx context.Context, d *schema.ResourceData, m i
 			projectId := d.Get("project_id").(int)
 			startDatecode := time.Now().Format("200601")
 
-			newId, err := convertCacheAccountToProjectAccount(client, accountCacheId, projectId, startDatecode)
+			newId, err := convertCacheAccountToProjectAccount(ctx, client, accountCacheId, projectId, startDatecode)
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to convert Azure cached account to project account",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), accountCacheId),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to convert Azure cached account to project account", err)...)
 				diags = append(diags, resourceAzureAccountRead(ctx, d, m)...)
 				return diags
 			}
@@ -432,14 +420,10 @@ func resourceAzureAccountCreate(ctx context.Context, d *schema.ResourceData, m i
 	if accountLocation == ProjectLocation {
 		if _, ok := d.GetOk("labels"); ok {
 			ID := d.Id()
-			err := hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "account", ID)
+			err := hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "account", ID)
 
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to update Azure account labels",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to update Azure account labels", err)...)
 				diags = append(diags, resourceAzureAccountRead(ctx, d, m)...)
 				return diags
 			}
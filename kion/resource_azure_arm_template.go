@@ -2,28 +2,31 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 func resourceAzureArmTemplate() *schema.Resource {
-	return &schema.Resource{
+	return withOwnerNames("owner_users", "owner_user_groups", &schema.Resource{
 		CreateContext: resourceAzureArmTemplateCreate,
 		ReadContext:   resourceAzureArmTemplateRead,
 		UpdateContext: resourceAzureArmTemplateUpdate,
 		DeleteContext: resourceAzureArmTemplateDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceAzureArmTemplateRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceAzureArmTemplateRead)
 			},
 		},
+		CustomizeDiff: validateReferences(
+			referenceTo("owner_users", "id", hc.ReferenceUser),
+			referenceTo("owner_user_groups", "id", hc.ReferenceUserGroup),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -99,7 +102,7 @@ func resourceAzureArmTemplate() *schema.Resource {
 				Computed: true,
 			},
 		},
-	}
+	})
 }
 
 func resourceAzureArmTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -118,19 +121,15 @@ func resourceAzureArmTemplateCreate(ctx context.Context, d *schema.ResourceData,
 		TemplateParameters:    d.Get("template_parameters").(string),
 	}
 
-	resp, err := client.POST("/v3/azure-arm-template", post)
+	resp, err := client.POSTContext(ctx, "/v3/azure-arm-template", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Azure ARM Template",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create Azure ARM Template", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create Azure ARM Template",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -148,13 +147,14 @@ func resourceAzureArmTemplateRead(ctx context.Context, d *schema.ResourceData, m
 	ID := d.Id()
 
 	resp := new(hc.AzureARMTemplateResponse)
-	err := client.GET(fmt.Sprintf("/v3/azure-arm-template/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/azure-arm-template/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Azure ARM Template",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "Azure ARM Template not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Azure ARM Template", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -178,11 +178,7 @@ func resourceAzureArmTemplateRead(ctx context.Context, d *schema.ResourceData, m
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set Azure ARM Template",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set Azure ARM Template", err)...)
 			return diags
 		}
 	}
@@ -215,13 +211,9 @@ func resourceAzureArmTemplateUpdate(ctx context.Context, d *schema.ResourceData,
 			TemplateParameters: d.Get("template_parameters").(string),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/azure-arm-template/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/azure-arm-template/%s", ID), req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update Azure ARM Template",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Azure ARM Template", err)...)
 			return diags
 		}
 	}
@@ -235,32 +227,24 @@ func resourceAzureArmTemplateUpdate(ctx context.Context, d *schema.ResourceData,
 
 		if len(arrAddOwnerUserGroupIds) > 0 ||
 			len(arrAddOwnerUserIds) > 0 {
-			_, err := client.POST(fmt.Sprintf("/v3/azure-arm-template/%s/owner", ID), hc.ChangeOwners{
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/azure-arm-template/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrAddOwnerUserGroupIds,
 				OwnerUserIds:      &arrAddOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add owners on Azure ARM Template",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add owners on Azure ARM Template", err)...)
 				return diags
 			}
 		}
 
 		if len(arrRemoveOwnerUserGroupIds) > 0 ||
 			len(arrRemoveOwnerUserIds) > 0 {
-			err := client.DELETE(fmt.Sprintf("/v3/azure-arm-template/%s/owner", ID), hc.ChangeOwners{
+			err := client.DELETEContext(ctx, fmt.Sprintf("/v3/azure-arm-template/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrRemoveOwnerUserGroupIds,
 				OwnerUserIds:      &arrRemoveOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove owners on Azure ARM Template",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove owners on Azure ARM Template", err)...)
 				return diags
 			}
 		}
@@ -285,13 +269,9 @@ func resourceAzureArmTemplateDelete(ctx context.Context, d *schema.ResourceData,
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/azure-arm-template/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/azure-arm-template/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete Azure ARM Template",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete Azure ARM Template", err)...)
 		return diags
 	}
 
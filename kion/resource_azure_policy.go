@@ -2,28 +2,31 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 func resourceAzurePolicy() *schema.Resource {
-	return &schema.Resource{
+	return withOwnerNames("owner_users", "owner_user_groups", &schema.Resource{
 		CreateContext: resourceAzurePolicyCreate,
 		ReadContext:   resourceAzurePolicyRead,
 		UpdateContext: resourceAzurePolicyUpdate,
 		DeleteContext: resourceAzurePolicyDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceAzurePolicyRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceAzurePolicyRead)
 			},
 		},
+		CustomizeDiff: validateReferences(
+			referenceTo("owner_users", "id", hc.ReferenceUser),
+			referenceTo("owner_user_groups", "id", hc.ReferenceUserGroup),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -84,7 +87,7 @@ func resourceAzurePolicy() *schema.Resource {
 				Required: true,
 			},
 		},
-	}
+	})
 }
 
 func resourceAzurePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -102,19 +105,15 @@ func resourceAzurePolicyCreate(ctx context.Context, d *schema.ResourceData, m in
 		OwnerUsers:      hc.FlattenGenericIDPointer(d, "owner_users"),
 	}
 
-	resp, err := client.POST("/v3/azure-policy", post)
+	resp, err := client.POSTContext(ctx, "/v3/azure-policy", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create AzurePolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create AzurePolicy", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create AzurePolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -132,13 +131,14 @@ func resourceAzurePolicyRead(ctx context.Context, d *schema.ResourceData, m inte
 	ID := d.Id()
 
 	resp := new(hc.AzurePolicyResponse)
-	err := client.GET(fmt.Sprintf("/v3/azure-policy/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/azure-policy/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read AzurePolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "AzurePolicy not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read AzurePolicy", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -159,11 +159,7 @@ func resourceAzurePolicyRead(ctx context.Context, d *schema.ResourceData, m inte
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set AzurePolicy",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set AzurePolicy", err)...)
 			return diags
 		}
 	}
@@ -194,13 +190,9 @@ func resourceAzurePolicyUpdate(ctx context.Context, d *schema.ResourceData, m in
 			Policy:      d.Get("policy").(string),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/azure-policy/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/azure-policy/%s", ID), req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update AzurePolicy",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update AzurePolicy", err)...)
 			return diags
 		}
 	}
@@ -214,32 +206,24 @@ func resourceAzurePolicyUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 		if len(arrAddOwnerUserGroupIds) > 0 ||
 			len(arrAddOwnerUserIds) > 0 {
-			_, err := client.POST(fmt.Sprintf("/v3/azure-policy/%s/owner", ID), hc.ChangeOwners{
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/azure-policy/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrAddOwnerUserGroupIds,
 				OwnerUserIds:      &arrAddOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add owners on AzurePolicy",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add owners on AzurePolicy", err)...)
 				return diags
 			}
 		}
 
 		if len(arrRemoveOwnerUserGroupIds) > 0 ||
 			len(arrRemoveOwnerUserIds) > 0 {
-			err := client.DELETE(fmt.Sprintf("/v3/azure-policy/%s/owner", ID), hc.ChangeOwners{
+			err := client.DELETEContext(ctx, fmt.Sprintf("/v3/azure-policy/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrRemoveOwnerUserGroupIds,
 				OwnerUserIds:      &arrRemoveOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove owners on AzurePolicy",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove owners on AzurePolicy", err)...)
 				return diags
 			}
 		}
@@ -264,13 +248,9 @@ func resourceAzurePolicyDelete(ctx context.Context, d *schema.ResourceData, m in
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/azure-policy/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/azure-policy/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete AzurePolicy",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete AzurePolicy", err)...)
 		return diags
 	}
 
@@ -3,29 +3,32 @@ package kion
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 func resourceAzureRole() *schema.Resource {
-	return &schema.Resource{
+	return withOwnerNames("owner_users", "owner_user_groups", &schema.Resource{
 		CreateContext: resourceAzureRoleCreate,
 		ReadContext:   resourceAzureRoleRead,
 		UpdateContext: resourceAzureRoleUpdate,
 		DeleteContext: resourceAzureRoleDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceAzureRoleRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceAzureRoleRead)
 			},
 		},
+		CustomizeDiff: validateReferences(
+			referenceTo("owner_users", "id", hc.ReferenceUser),
+			referenceTo("owner_user_groups", "id", hc.ReferenceUserGroup),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -96,7 +99,7 @@ func resourceAzureRole() *schema.Resource {
 				Computed: true,
 			},
 		},
-	}
+	})
 }
 
 func resourceAzureRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -111,19 +114,15 @@ func resourceAzureRoleCreate(ctx context.Context, d *schema.ResourceData, m inte
 		RolePermissions:   d.Get("role_permissions").(string),
 	}
 
-	resp, err := client.POST("/v3/azure-role", post)
+	resp, err := client.POSTContext(ctx, "/v3/azure-role", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create AzureRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create AzureRole", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create AzureRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -141,13 +140,14 @@ func resourceAzureRoleRead(ctx context.Context, d *schema.ResourceData, m interf
 	ID := d.Id()
 
 	resp := new(hc.AzureRoleResponse)
-	err := client.GET(fmt.Sprintf("/v3/azure-role/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/azure-role/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read AzureRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "AzureRole not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read AzureRole", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -167,11 +167,7 @@ func resourceAzureRoleRead(ctx context.Context, d *schema.ResourceData, m interf
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set AzureRole",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set AzureRole", err)...)
 			return diags
 		}
 	}
@@ -200,13 +196,9 @@ func resourceAzureRoleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 			RolePermissions: d.Get("role_permissions").(string),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/azure-role/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/azure-role/%s", ID), req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update AzureRole",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update AzureRole", err)...)
 			return diags
 		}
 	}
@@ -220,32 +212,24 @@ func resourceAzureRoleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 
 		if len(arrAddOwnerUserGroupIds) > 0 ||
 			len(arrAddOwnerUserIds) > 0 {
-			_, err := client.POST(fmt.Sprintf("/v3/azure-role/%s/owner", ID), hc.ChangeOwners{
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/azure-role/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrAddOwnerUserGroupIds,
 				OwnerUserIds:      &arrAddOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add owners on AzureRole",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add owners on AzureRole", err)...)
 				return diags
 			}
 		}
 
 		if len(arrRemoveOwnerUserGroupIds) > 0 ||
 			len(arrRemoveOwnerUserIds) > 0 {
-			err := client.DELETE(fmt.Sprintf("/v3/azure-role/%s/owner", ID), hc.ChangeOwners{
+			err := client.DELETEContext(ctx, fmt.Sprintf("/v3/azure-role/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrRemoveOwnerUserGroupIds,
 				OwnerUserIds:      &arrRemoveOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove owners on AzureRole",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove owners on AzureRole", err)...)
 				return diags
 			}
 		}
@@ -270,13 +254,9 @@ func resourceAzureRoleDelete(ctx context.Context, d *schema.ResourceData, m inte
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/azure-role/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/azure-role/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete AzureRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete AzureRole", err)...)
 		return diags
 	}
 
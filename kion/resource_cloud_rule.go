@@ -2,28 +2,38 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 func resourceCloudRule() *schema.Resource {
-	return &schema.Resource{
+	return withOwnerNames("owner_users", "owner_user_groups", &schema.Resource{
 		CreateContext: resourceCloudRuleCreate,
 		ReadContext:   resourceCloudRuleRead,
 		UpdateContext: resourceCloudRuleUpdate,
 		DeleteContext: resourceCloudRuleDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceCloudRuleRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceCloudRuleRead)
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			requireKionVersionForAttribute("kion_cloud_rule", "labels", hc.VersionLabels),
+			validateReferences(
+				referenceTo("owner_users", "id", hc.ReferenceUser),
+				referenceTo("owner_user_groups", "id", hc.ReferenceUserGroup),
+				referenceTo("post_webhook_id", "", hc.ReferenceWebhook),
+				referenceTo("pre_webhook_id", "", hc.ReferenceWebhook),
+				labelReference("labels"),
+			),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -192,12 +202,14 @@ func resourceCloudRule() *schema.Resource {
 				AtLeastOneOf: []string{"owner_user_groups", "owner_users"},
 			},
 			"post_webhook_id": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "ID of the webhook Kion calls after the cloud rule is applied, e.g. kion_webhook.example.id.",
 			},
 			"pre_webhook_id": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "ID of the webhook Kion calls before the cloud rule is applied, e.g. kion_webhook.example.id.",
 			},
 			"projects": {
 				Elem: &schema.Resource{
@@ -230,7 +242,7 @@ func resourceCloudRule() *schema.Resource {
 				Description: "A map of labels to assign to the cloud rule. The labels must already exist in Kion.",
 			},
 		},
-	}
+	})
 }
 
 func resourceCloudRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -274,19 +286,15 @@ func resourceCloudRuleCreate(ctx context.Context, d *schema.ResourceData, m inte
 		ServiceControlPolicyIds:       hc.FlattenGenericIDPointer(d, "service_control_policies"),
 	}
 
-	resp, err := client.POST("/v3/cloud-rule", post)
+	resp, err := client.POSTContext(ctx, "/v3/cloud-rule", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create CloudRule",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create CloudRule", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create CloudRule",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -295,14 +303,10 @@ func resourceCloudRuleCreate(ctx context.Context, d *schema.ResourceData, m inte
 
 	if labels, ok := d.GetOk("labels"); ok && labels != nil {
 		ID := d.Id()
-		err = hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "cloud-rule", ID)
+		err = hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "cloud-rule", ID)
 
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update Cloud Rule labels",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Cloud Rule labels", err)...)
 			return diags
 		}
 	}
@@ -318,13 +322,14 @@ func resourceCloudRuleRead(ctx context.Context, d *schema.ResourceData, m interf
 	ID := d.Id()
 
 	resp := new(hc.CloudRuleResponse)
-	err := client.GET(fmt.Sprintf("/v3/cloud-rule/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/cloud-rule/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read CloudRule",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "CloudRule not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read CloudRule", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -386,35 +391,23 @@ func resourceCloudRuleRead(ctx context.Context, d *schema.ResourceData, m interf
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set CloudRule",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set CloudRule", err)...)
 			return diags
 		}
 	}
 
 	// Fetch labels
-	labelData, err := hc.ReadResourceLabels(client, "cloud-rule", ID)
+	labelData, err := hc.ReadResourceLabels(ctx, client, "cloud-rule", ID)
 
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read cloud rule labels",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read cloud rule labels", err)...)
 		return diags
 	}
 
 	// Set labels
 	err = d.Set("labels", labelData)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set labels for cloud rule",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set labels for cloud rule", err)...)
 	}
 
 	return diags
@@ -440,12 +433,8 @@ func resourceCloudRuleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 			PostWebhookID: hc.FlattenIntPointer(d, "post_webhook_id"),
 			PreWebhookID:  hc.FlattenIntPointer(d, "pre_webhook_id"),
 		}
-		if err := client.PATCH(fmt.Sprintf("/v3/cloud-rule/%s", ID), req); err != nil {
-			return append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update CloudRule",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+		if err := client.PATCHContext(ctx, fmt.Sprintf("/v3/cloud-rule/%s", ID), req); err != nil {
+			return append(diags, apiErrorDiagnostics(d, "Unable to update CloudRule", err)...)
 		}
 	}
 
@@ -453,7 +442,7 @@ func resourceCloudRuleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	if d.HasChange("aws_cloudformation_templates") {
 		newCftIDs := extractCFTandARMTemplateIDs(d, "aws_cloudformation_templates")
 		if len(newCftIDs) > 0 {
-			if err := updateCFTandARMTemplateAssociations(client, ID, newCftIDs, "CFT"); err != nil {
+			if err := updateCFTandARMTemplateAssociations(ctx, client, ID, newCftIDs, "CFT"); err != nil {
 				return append(diags, err...)
 			}
 		}
@@ -463,7 +452,7 @@ func resourceCloudRuleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	if d.HasChange("azure_arm_template_definitions") {
 		newArmTemplateIDs := extractCFTandARMTemplateIDs(d, "azure_arm_template_definitions")
 		if len(newArmTemplateIDs) > 0 {
-			if err := updateCFTandARMTemplateAssociations(client, ID, newArmTemplateIDs, "ARM"); err != nil {
+			if err := updateCFTandARMTemplateAssociations(ctx, client, ID, newArmTemplateIDs, "ARM"); err != nil {
 				return append(diags, err...)
 			}
 		}
@@ -508,7 +497,7 @@ func resourceCloudRuleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 			len(arrAddOUIds) > 0 ||
 			len(arrAddProjectIds) > 0 ||
 			len(arrAddServiceControlPolicyIds) > 0 {
-			_, err := client.POST(fmt.Sprintf("/v3/cloud-rule/%s/association", ID), hc.CloudRuleAssociationsAdd{
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/cloud-rule/%s/association", ID), hc.CloudRuleAssociationsAdd{
 				AzurePolicyDefinitionIds: &arrAddAzurePolicyDefinitionIds,
 				AzureRoleDefinitionIds:   &arrAddAzureRoleDefinitionIds,
 				ComplianceStandardIds:    &arrAddComplianceStandardIds,
@@ -521,11 +510,7 @@ func resourceCloudRuleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 				ServiceControlPolicyIds:  &arrAddServiceControlPolicyIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add owners on CloudRule",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add owners on CloudRule", err)...)
 				return diags
 			}
 		}
@@ -542,7 +527,7 @@ func resourceCloudRuleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 			len(arrRemoveOUIds) > 0 ||
 			len(arrRemoveProjectIds) > 0 ||
 			len(arrRemoveServiceControlPolicyIds) > 0 {
-			err := client.DELETE(fmt.Sprintf("/v3/cloud-rule/%s/association", ID), hc.CloudRuleAssociationsRemove{
+			err := client.DELETEContext(ctx, fmt.Sprintf("/v3/cloud-rule/%s/association", ID), hc.CloudRuleAssociationsRemove{
 				AzureArmTemplateDefinitionIds: &arrRemoveAzureArmTemplateDefinitionIds,
 				AzurePolicyDefinitionIds:      &arrRemoveAzurePolicyDefinitionIds,
 				AzureRoleDefinitionIds:        &arrRemoveAzureRoleDefinitionIds,
@@ -557,11 +542,7 @@ func resourceCloudRuleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 				ServiceControlPolicyIds:       &arrRemoveServiceControlPolicyIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove Associations on CloudRule",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove Associations on CloudRule", err)...)
 				return diags
 			}
 		}
@@ -576,32 +557,24 @@ func resourceCloudRuleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 
 		if len(arrAddOwnerUserGroupIds) > 0 ||
 			len(arrAddOwnerUserIds) > 0 {
-			_, err := client.POST(fmt.Sprintf("/v3/cloud-rule/%s/owner", ID), hc.ChangeOwners{
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/cloud-rule/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrAddOwnerUserGroupIds,
 				OwnerUserIds:      &arrAddOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add owners on CloudRule",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add owners on CloudRule", err)...)
 				return diags
 			}
 		}
 
 		if len(arrRemoveOwnerUserGroupIds) > 0 ||
 			len(arrRemoveOwnerUserIds) > 0 {
-			err := client.DELETE(fmt.Sprintf("/v3/cloud-rule/%s/owner", ID), hc.ChangeOwners{
+			err := client.DELETEContext(ctx, fmt.Sprintf("/v3/cloud-rule/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrRemoveOwnerUserGroupIds,
 				OwnerUserIds:      &arrRemoveOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove owners on CloudRule",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove owners on CloudRule", err)...)
 				return diags
 			}
 		}
@@ -610,14 +583,10 @@ func resourceCloudRuleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	if d.HasChanges("labels") {
 		hasChanged++
 
-		err := hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "cloud-rule", ID)
+		err := hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "cloud-rule", ID)
 
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update cloud rule labels",
-				Detail:   fmt.Sprintf("Error: %v\nCloud rule ID: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update cloud rule labels", err)...)
 			return diags
 		}
 	}
@@ -641,13 +610,9 @@ func resourceCloudRuleDelete(ctx context.Context, d *schema.ResourceData, m inte
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/cloud-rule/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/cloud-rule/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete CloudRule",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete CloudRule", err)...)
 		return diags
 	}
 
@@ -668,7 +633,7 @@ func extractCFTandARMTemplateIDs(d *schema.ResourceData, key string) []int {
 	return ids
 }
 
-func updateCFTandARMTemplateAssociations(client *hc.Client, ID string, ids []int, templateType string) diag.Diagnostics {
+func updateCFTandARMTemplateAssociations(ctx context.Context, client *hc.Client, ID string, ids []int, templateType string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	cloudRuleAssocationEndpoint := fmt.Sprintf("/v3/cloud-rule/%s/association", ID)
 	reqBody := hc.CloudRuleAssociationsAdd{}
@@ -677,12 +642,8 @@ func updateCFTandARMTemplateAssociations(client *hc.Client, ID string, ids []int
 	} else {
 		reqBody.AzureArmTemplateDefinitionIds = &ids
 	}
-	if _, err := client.POST(cloudRuleAssocationEndpoint, reqBody); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Unable to update %s templates association", templateType),
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+	if _, err := client.POSTContext(ctx, cloudRuleAssocationEndpoint, reqBody); err != nil {
+		diags = append(diags, apiErrorDiagnostics(nil, fmt.Sprintf("Unable to update %s templates association", templateType), err)...)
 	}
 	return diags
 }
@@ -2,28 +2,31 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 func resourceComplianceCheck() *schema.Resource {
-	return &schema.Resource{
+	return withOwnerNames("owner_users", "owner_user_groups", &schema.Resource{
 		CreateContext: resourceComplianceCheckCreate,
 		ReadContext:   resourceComplianceCheckRead,
 		UpdateContext: resourceComplianceCheckUpdate,
 		DeleteContext: resourceComplianceCheckDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceComplianceCheckRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceComplianceCheckRead)
 			},
 		},
+		CustomizeDiff: validateReferences(
+			referenceTo("owner_users", "id", hc.ReferenceUser),
+			referenceTo("owner_user_groups", "id", hc.ReferenceUserGroup),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -129,7 +132,7 @@ func resourceComplianceCheck() *schema.Resource {
 				Default:  3,
 			},
 		},
-	}
+	})
 }
 
 func resourceComplianceCheckCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -154,19 +157,15 @@ func resourceComplianceCheckCreate(ctx context.Context, d *schema.ResourceData,
 		SeverityTypeID:        hc.FlattenIntPointer(d, "severity_type_id"),
 	}
 
-	resp, err := client.POST("/v3/compliance/check", post)
+	resp, err := client.POSTContext(ctx, "/v3/compliance/check", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create ComplianceCheck",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create ComplianceCheck", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create ComplianceCheck",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -184,13 +183,14 @@ func resourceComplianceCheckRead(ctx context.Context, d *schema.ResourceData, m
 	ID := d.Id()
 
 	resp := new(hc.ComplianceCheckWithOwnersResponse)
-	err := client.GET(fmt.Sprintf("/v3/compliance/check/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/compliance/check/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read ComplianceCheck",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "ComplianceCheck not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read ComplianceCheck", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -225,11 +225,7 @@ func resourceComplianceCheckRead(ctx context.Context, d *schema.ResourceData, m
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set ComplianceCheck",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set ComplianceCheck", err)...)
 			return diags
 		}
 	}
@@ -276,13 +272,9 @@ func resourceComplianceCheckUpdate(ctx context.Context, d *schema.ResourceData,
 			SeverityTypeID:        hc.FlattenIntPointer(d, "severity_type_id"),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/compliance/check/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/compliance/check/%s", ID), req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update ComplianceCheck",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update ComplianceCheck", err)...)
 			return diags
 		}
 	}
@@ -296,32 +288,24 @@ func resourceComplianceCheckUpdate(ctx context.Context, d *schema.ResourceData,
 
 		if len(arrAddOwnerUserGroupIds) > 0 ||
 			len(arrAddOwnerUserIds) > 0 {
-			_, err := client.POST(fmt.Sprintf("/v3/compliance/check/%s/owner", ID), hc.ChangeOwners{
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/compliance/check/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrAddOwnerUserGroupIds,
 				OwnerUserIds:      &arrAddOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add owners on ComplianceCheck",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add owners on ComplianceCheck", err)...)
 				return diags
 			}
 		}
 
 		if len(arrRemoveOwnerUserGroupIds) > 0 ||
 			len(arrRemoveOwnerUserIds) > 0 {
-			err := client.DELETE(fmt.Sprintf("/v3/compliance/check/%s/owner", ID), hc.ChangeOwners{
+			err := client.DELETEContext(ctx, fmt.Sprintf("/v3/compliance/check/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrRemoveOwnerUserGroupIds,
 				OwnerUserIds:      &arrRemoveOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove owners on ComplianceCheck",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove owners on ComplianceCheck", err)...)
 				return diags
 			}
 		}
@@ -346,13 +330,9 @@ func resourceComplianceCheckDelete(ctx context.Context, d *schema.ResourceData,
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/compliance/check/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/compliance/check/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete ComplianceCheck",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete ComplianceCheck", err)...)
 		return diags
 	}
 
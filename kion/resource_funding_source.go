@@ -2,28 +2,38 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 func resourceFundingSource() *schema.Resource {
-	return &schema.Resource{
+	return withOwnerNames("owner_users", "owner_user_groups", &schema.Resource{
 		CreateContext: resourceFundingSourceCreate,
 		ReadContext:   resourceFundingSourceRead,
 		UpdateContext: resourceFundingSourceUpdate,
 		DeleteContext: resourceFundingSourceDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceFundingSourceRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceFundingSourceRead)
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			requireKionVersionForAttribute("kion_funding_source", "labels", hc.VersionLabels),
+			validateReferences(
+				referenceTo("ou_id", "", hc.ReferenceOU),
+				referenceTo("permission_scheme_id", "", hc.ReferencePermissionScheme),
+				referenceTo("owner_users", "id", hc.ReferenceUser),
+				referenceTo("owner_user_groups", "id", hc.ReferenceUserGroup),
+				labelReference("labels"),
+			),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -94,7 +104,7 @@ func resourceFundingSource() *schema.Resource {
 				Description: "A map of labels to assign to the funding source. The labels must already exist in Kion.",
 			},
 		},
-	}
+	})
 }
 
 func resourceFundingSourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -113,19 +123,15 @@ func resourceFundingSourceCreate(ctx context.Context, d *schema.ResourceData, m
 		OwnerUserGroupIds:  hc.FlattenGenericIDPointer(d, "owner_user_groups"),
 	}
 
-	resp, err := client.POST("/v3/funding-source", post)
+	resp, err := client.POSTContext(ctx, "/v3/funding-source", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Funding Source",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create Funding Source", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create Funding Source",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -134,14 +140,10 @@ func resourceFundingSourceCreate(ctx context.Context, d *schema.ResourceData, m
 
 	if labels, ok := d.GetOk("labels"); ok && labels != nil {
 		ID := d.Id()
-		err = hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "funding-source", ID)
+		err = hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "funding-source", ID)
 
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update Funding Source labels",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Funding Source labels", err)...)
 			return diags
 		}
 	}
@@ -157,13 +159,14 @@ func resourceFundingSourceRead(ctx context.Context, d *schema.ResourceData, m in
 	ID := d.Id()
 
 	resp := new(hc.FundingSourceResponse)
-	err := client.GET(fmt.Sprintf("/v3/funding-source/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/funding-source/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Funding Source",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "Funding Source not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Funding Source", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -177,13 +180,9 @@ func resourceFundingSourceRead(ctx context.Context, d *schema.ResourceData, m in
 	data["end_datecode"] = item.EndDatecode
 
 	permissionResp := new(hc.FSUserMappingListResponse)
-	err = client.GET(fmt.Sprintf("/v3/funding-source/%s/permission-mapping", ID), permissionResp)
+	err = client.GETContext(ctx, fmt.Sprintf("/v3/funding-source/%s/permission-mapping", ID), permissionResp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Funding Source permissions",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Funding Source permissions", err)...)
 		return diags
 	}
 
@@ -200,35 +199,23 @@ func resourceFundingSourceRead(ctx context.Context, d *schema.ResourceData, m in
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set Funding Source",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set Funding Source", err)...)
 			return diags
 		}
 	}
 
 	// Fetch labels
-	labelData, err := hc.ReadResourceLabels(client, "funding-source", ID)
+	labelData, err := hc.ReadResourceLabels(ctx, client, "funding-source", ID)
 
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read funding source labels",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read funding source labels", err)...)
 		return diags
 	}
 
 	// Set labels
 	err = d.Set("labels", labelData)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set labels for funding source",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set labels for funding source", err)...)
 	}
 
 	return diags
@@ -249,15 +236,9 @@ func resourceFundingSourceUpdate(ctx context.Context, d *schema.ResourceData, m
 			StartDatecode: d.Get("start_datecode").(string),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/funding-source/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/funding-source/%s", ID), req)
 		if err != nil {
-			return diag.Diagnostics{
-				{
-					Severity: diag.Error,
-					Summary:  "Unable to update Funding Source",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				},
-			}
+			return apiErrorDiagnostics(d, "Unable to update Funding Source", err)
 		}
 	}
 
@@ -275,30 +256,18 @@ func resourceFundingSourceUpdate(ctx context.Context, d *schema.ResourceData, m
 				},
 			}
 
-			err := client.PATCH(fmt.Sprintf("/v3/funding-source/%s/permission-mapping", ID), patch)
+			err := client.PATCHContext(ctx, fmt.Sprintf("/v3/funding-source/%s/permission-mapping", ID), patch)
 			if err != nil {
-				return diag.Diagnostics{
-					{
-						Severity: diag.Error,
-						Summary:  "Unable to change permission mapping on Funding Source",
-						Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-					},
-				}
+				return apiErrorDiagnostics(d, "Unable to change permission mapping on Funding Source", err)
 			}
 		}
 	}
 
 	// Check for label changes and update accordingly
 	if d.HasChanges("labels") {
-		err := hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "funding-source", ID)
+		err := hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "funding-source", ID)
 		if err != nil {
-			return diag.Diagnostics{
-				{
-					Severity: diag.Error,
-					Summary:  "Unable to update funding source labels",
-					Detail:   fmt.Sprintf("Error: %v\nFunding source ID: %v", err.Error(), ID),
-				},
-			}
+			return apiErrorDiagnostics(d, "Unable to update funding source labels", err)
 		}
 	}
 
@@ -322,13 +291,9 @@ func resourceFundingSourceDelete(ctx context.Context, d *schema.ResourceData, m
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/funding-source/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/funding-source/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete Funding Source",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete Funding Source", err)...)
 		return diags
 	}
 
@@ -3,7 +3,6 @@ package kion
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -42,8 +41,7 @@ func resourceGcpAccount() *schema.Resource {
 		DeleteContext: resourceGcpAccountDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceGcpAccountRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceGcpAccountRead)
 			},
 		},
 		Schema: map[string]*schema.Schema{
@@ -141,6 +139,12 @@ func resourceGcpAccount() *schema.Resource {
 			// schema validators don't support multi-attribute validations, so we use CustomizeDiff instead
 			validateGcpAccountStartDatecode,
 			customDiffComputedAccountLocation,
+			requireKionVersion("kion_gcp_account", hc.VersionAccounts),
+			validateReferences(
+				referenceTo("payer_id", "", hc.ReferencePayer),
+				referenceTo("project_id", "", hc.ReferenceProject),
+				labelReference("labels"),
+			),
 		),
 	}
 }
@@ -185,19 +189,15 @@ func resourceGcpAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 		if rb, err := json.Marshal(postAccountData); err == nil {
 			tflog.Debug(ctx, fmt.Sprintf("Importing exiting GCP Project via POST %s", accountUrl), map[string]interface{}{"postData": string(rb)})
 		}
-		resp, err := client.POST(accountUrl, postAccountData)
+		resp, err := client.POSTContext(ctx, accountUrl, postAccountData)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to import GCP Project",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), postAccountData),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to import GCP Project", err)...)
 			return diags
 		} else if resp.RecordID == 0 {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "Unable to import GCP Project",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), postAccountData),
+				Detail:   "Kion returned an item ID of 0.",
 			})
 			return diags
 		}
@@ -221,19 +221,15 @@ func resourceGcpAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 		if rb, err := json.Marshal(postCacheData); err == nil {
 			tflog.Debug(ctx, "Creating new GCP account via POST /v3/account-cache/create?account-type=google-cloud", map[string]interface{}{"data": string(rb)})
 		}
-		respCache, err := client.POST("/v3/account-cache/create?account-type=google-cloud", postCacheData)
+		respCache, err := client.POSTContext(ctx, "/v3/account-cache/create?account-type=google-cloud", postCacheData)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to create GCP Project",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), postCacheData),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to create GCP Project", err)...)
 			return diags
 		} else if respCache.RecordID == 0 {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "Unable to create GCP Project",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), postCacheData),
+				Detail:   "Kion returned an item ID of 0.",
 			})
 			return diags
 		}
@@ -246,7 +242,7 @@ func resourceGcpAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 		createStateConf := &retry.StateChangeConf{
 			Refresh: func() (interface{}, string, error) {
 				resp := new(hc.AccountResponse)
-				err := client.GET(fmt.Sprintf("/v3/account-cache/%d", accountCacheId), resp)
+				err := client.GETContext(ctx, fmt.Sprintf("/v3/account-cache/%d", accountCacheId), resp)
 				if err != nil {
 					if resErr, ok := err.(*hc.RequestError); ok {
 						if resErr.StatusCode == http.StatusNotFound {
@@ -268,11 +264,7 @@ func resourceGcpAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 		}
 		_, err = createStateConf.WaitForStateContext(ctx)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to create GCP Project",
-				Detail:   fmt.Sprintf("Error: %v", err.Error()),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to create GCP Project", err)...)
 			return diags
 		}
 
@@ -282,13 +274,9 @@ func resourceGcpAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 			projectId := d.Get("project_id").(int)
 			startDatecode := time.Now().Format("200601")
 
-			newId, err := convertCacheAccountToProjectAccount(client, accountCacheId, projectId, startDatecode)
+			newId, err := convertCacheAccountToProjectAccount(ctx, client, accountCacheId, projectId, startDatecode)
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to convert GCP cached account to project account",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), accountCacheId),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to convert GCP cached account to project account", err)...)
 				diags = append(diags, resourceGcpAccountRead(ctx, d, m)...)
 				return diags
 			}
@@ -321,14 +309,10 @@ func resourceGcpAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 	if accountLocation == ProjectLocation {
 		if _, ok := d.GetOk("labels"); ok {
 			ID := d.Id()
-			err := hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "account", ID)
+			err := hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "account", ID)
 
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to update GCP account labels",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to update GCP account labels", err)...)
 				diags = append(diags, resourceGcpAccountRead(ctx, d, m)...)
 				return diags
 			}
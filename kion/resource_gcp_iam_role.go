@@ -2,28 +2,31 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 func resourceGcpIamRole() *schema.Resource {
-	return &schema.Resource{
+	return withOwnerNames("owner_users", "owner_user_groups", &schema.Resource{
 		CreateContext: resourceGcpIamRoleCreate,
 		ReadContext:   resourceGcpIamRoleRead,
 		UpdateContext: resourceGcpIamRoleUpdate,
 		DeleteContext: resourceGcpIamRoleDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceGcpIamRoleRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceGcpIamRoleRead)
 			},
 		},
+		CustomizeDiff: validateReferences(
+			referenceTo("owner_users", "id", hc.ReferenceUser),
+			referenceTo("owner_user_groups", "id", hc.ReferenceUserGroup),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -89,7 +92,7 @@ func resourceGcpIamRole() *schema.Resource {
 				AtLeastOneOf: []string{"owner_user_groups", "owner_users"},
 			},
 		},
-	}
+	})
 }
 
 func resourceGcpIamRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -105,19 +108,15 @@ func resourceGcpIamRoleCreate(ctx context.Context, d *schema.ResourceData, m int
 		GCPRoleLaunchStage: d.Get("gcp_role_launch_stage").(int),
 	}
 
-	resp, err := client.POST("/v3/gcp-iam-role", post)
+	resp, err := client.POSTContext(ctx, "/v3/gcp-iam-role", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create GcpIamRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create GcpIamRole", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create GcpIamRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -135,13 +134,14 @@ func resourceGcpIamRoleRead(ctx context.Context, d *schema.ResourceData, m inter
 	ID := d.Id()
 
 	resp := new(hc.GCPRoleResponseWithOwners)
-	err := client.GET(fmt.Sprintf("/v3/gcp-iam-role/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/gcp-iam-role/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read GcpIamRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "GcpIamRole not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read GcpIamRole", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -163,11 +163,7 @@ func resourceGcpIamRoleRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set GcpIamRole",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v\nData: %+v\nPayload: %+v", err.Error(), ID, data, resp),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set GcpIamRole", err)...)
 			return diags
 		}
 	}
@@ -199,13 +195,9 @@ func resourceGcpIamRoleUpdate(ctx context.Context, d *schema.ResourceData, m int
 			RolePermissions:    hc.FlattenStringArray(d.Get("role_permissions").(*schema.Set).List()),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/gcp-iam-role/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/gcp-iam-role/%s", ID), req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update GcpIamRole",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update GcpIamRole", err)...)
 			return diags
 		}
 	}
@@ -219,32 +211,24 @@ func resourceGcpIamRoleUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 		if len(arrAddOwnerUserGroupIds) > 0 ||
 			len(arrAddOwnerUserIds) > 0 {
-			_, err := client.POST(fmt.Sprintf("/v3/gcp-iam-role/%s/owner", ID), hc.ChangeOwners{
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/gcp-iam-role/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrAddOwnerUserGroupIds,
 				OwnerUserIds:      &arrAddOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add owners on GcpIamRole",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add owners on GcpIamRole", err)...)
 				return diags
 			}
 		}
 
 		if len(arrRemoveOwnerUserGroupIds) > 0 ||
 			len(arrRemoveOwnerUserIds) > 0 {
-			err := client.DELETE(fmt.Sprintf("/v3/gcp-iam-role/%s/owner", ID), hc.ChangeOwners{
+			err := client.DELETEContext(ctx, fmt.Sprintf("/v3/gcp-iam-role/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrRemoveOwnerUserGroupIds,
 				OwnerUserIds:      &arrRemoveOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove owners on GcpIamRole",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove owners on GcpIamRole", err)...)
 				return diags
 			}
 		}
@@ -269,13 +253,9 @@ func resourceGcpIamRoleDelete(ctx context.Context, d *schema.ResourceData, m int
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/gcp-iam-role/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/gcp-iam-role/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete GcpIamRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete GcpIamRole", err)...)
 		return diags
 	}
 
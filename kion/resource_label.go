@@ -2,11 +2,11 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -21,8 +21,7 @@ func resourceLabel() *schema.Resource {
 		DeleteContext: resourceLabelDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceLabelRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceLabelRead)
 			},
 		},
 		Schema: map[string]*schema.Schema{
@@ -58,19 +57,15 @@ func resourceLabelCreate(ctx context.Context, d *schema.ResourceData, m interfac
 		Value: d.Get("value").(string),
 	}
 
-	resp, err := client.POST("/v3/label", post)
+	resp, err := client.POSTContext(ctx, "/v3/label", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Label",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create Label", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create Label",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -88,41 +83,30 @@ func resourceLabelRead(ctx context.Context, d *schema.ResourceData, m interface{
 	ID := d.Id()
 
 	resp := new(hc.LabelResponse)
-	err := client.GET(fmt.Sprintf("/v3/label/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/label/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Label",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "Label not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Label", err)...)
 		return diags
 	}
 	label := resp.Data
 
 	if err := d.Set("key", label.Key); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set key for label",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set key for label", err)...)
 		return diags
 	}
 
 	if err := d.Set("value", label.Value); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set value for label",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set value for label", err)...)
 		return diags
 	}
 
 	if err := d.Set("color", label.Color); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set color for label",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set color for label", err)...)
 		return diags
 	}
 
@@ -144,13 +128,9 @@ func resourceLabelUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 			Value: d.Get("value").(string),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/label/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/label/%s", ID), req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update Label",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Label", err)...)
 			return diags
 		}
 	}
@@ -167,13 +147,9 @@ func resourceLabelDelete(ctx context.Context, d *schema.ResourceData, m interfac
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/label/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/label/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete label",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete label", err)...)
 		return diags
 	}
 
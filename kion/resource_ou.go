@@ -2,28 +2,38 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 func resourceOU() *schema.Resource {
-	return &schema.Resource{
+	return withOwnerNames("owner_users", "owner_user_groups", &schema.Resource{
 		CreateContext: resourceOUCreate,
 		ReadContext:   resourceOURead,
 		UpdateContext: resourceOUUpdate,
 		DeleteContext: resourceOUDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceOURead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceOURead)
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			requireKionVersionForAttribute("kion_ou", "labels", hc.VersionLabels),
+			validateReferences(
+				referenceTo("parent_ou_id", "", hc.ReferenceOU),
+				referenceTo("permission_scheme_id", "", hc.ReferencePermissionScheme),
+				referenceTo("owner_users", "id", hc.ReferenceUser),
+				referenceTo("owner_user_groups", "id", hc.ReferenceUserGroup),
+				labelReference("labels"),
+			),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -87,7 +97,7 @@ func resourceOU() *schema.Resource {
 				Description: "A map of labels to assign to the OU. The labels must already exist in Kion.",
 			},
 		},
-	}
+	})
 }
 
 func resourceOUCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -103,19 +113,15 @@ func resourceOUCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 		PermissionSchemeID: d.Get("permission_scheme_id").(int),
 	}
 
-	resp, err := client.POST("/v3/ou", post)
+	resp, err := client.POSTContext(ctx, "/v3/ou", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create OU",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create OU", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create OU",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -124,14 +130,10 @@ func resourceOUCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	if labels, ok := d.GetOk("labels"); ok && labels != nil {
 		ID := d.Id()
-		err = hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "ou", ID)
+		err = hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "ou", ID)
 
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update OU labels",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update OU labels", err)...)
 			return diags
 		}
 	}
@@ -147,13 +149,14 @@ func resourceOURead(ctx context.Context, d *schema.ResourceData, m interface{})
 	ID := d.Id()
 
 	resp := new(hc.OUResponse)
-	err := client.GET(fmt.Sprintf("/v3/ou/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/ou/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read OU",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "OU not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read OU", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -174,35 +177,23 @@ func resourceOURead(ctx context.Context, d *schema.ResourceData, m interface{})
 	for k, v := range data {
 		err = d.Set(k, v) // Use assignment instead of short declaration
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set OU",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set OU", err)...)
 			return diags
 		}
 	}
 
 	// Fetch labels
-	labelData, err := hc.ReadResourceLabels(client, "ou", ID)
+	labelData, err := hc.ReadResourceLabels(ctx, client, "ou", ID)
 
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read OU labels",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read OU labels", err)...)
 		return diags
 	}
 
 	// Set labels
 	err = d.Set("labels", labelData)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set labels for OU",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set labels for OU", err)...)
 	}
 
 	return diags
@@ -229,20 +220,16 @@ func resourceOUUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 			PermissionSchemeID: d.Get("permission_scheme_id").(int),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/ou/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/ou/%s", ID), req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update OU",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update OU", err)...)
 			return diags
 		}
 	}
 
 	// Allow moving an OU if the parent ID changes and updating permissions.
 	// Don't let codegen remove this.
-	diags, hasChanged = OUChanges(client, d, diags, hasChanged)
+	diags, hasChanged = OUChanges(ctx, client, d, diags, hasChanged)
 	if len(diags) > 0 {
 		return diags
 	}
@@ -256,32 +243,24 @@ func resourceOUUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 
 		if len(arrAddOwnerUserGroupIds) > 0 ||
 			len(arrAddOwnerUserIds) > 0 {
-			_, err := client.POST(fmt.Sprintf("/v3/ou/%s/owner", ID), hc.ChangeOwners{
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/ou/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrAddOwnerUserGroupIds,
 				OwnerUserIds:      &arrAddOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add owners on OU",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add owners on OU", err)...)
 				return diags
 			}
 		}
 
 		if len(arrRemoveOwnerUserGroupIds) > 0 ||
 			len(arrRemoveOwnerUserIds) > 0 {
-			err := client.DELETE(fmt.Sprintf("/v3/ou/%s/owner", ID), hc.ChangeOwners{
+			err := client.DELETEContext(ctx, fmt.Sprintf("/v3/ou/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrRemoveOwnerUserGroupIds,
 				OwnerUserIds:      &arrRemoveOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove owners on OU",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove owners on OU", err)...)
 				return diags
 			}
 		}
@@ -290,14 +269,10 @@ func resourceOUUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 	if d.HasChanges("labels") {
 		hasChanged++
 
-		err := hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "ou", ID)
+		err := hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "ou", ID)
 
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update OU labels",
-				Detail:   fmt.Sprintf("Error: %v\nOU ID: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update OU labels", err)...)
 			return diags
 		}
 	}
@@ -321,13 +296,9 @@ func resourceOUDelete(ctx context.Context, d *schema.ResourceData, m interface{}
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v2/ou/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v2/ou/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete OU",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete OU", err)...)
 		return diags
 	}
 
@@ -2,11 +2,11 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
@@ -20,10 +20,14 @@ func resourceOUCloudAccessRole() *schema.Resource {
 		DeleteContext: resourceOUCloudAccessRoleDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceOUCloudAccessRoleRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceOUCloudAccessRoleRead)
 			},
 		},
+		CustomizeDiff: validateReferences(
+			referenceTo("ou_id", "", hc.ReferenceOU),
+			referenceTo("users", "id", hc.ReferenceUser),
+			referenceTo("user_groups", "id", hc.ReferenceUserGroup),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -152,20 +156,16 @@ func resourceOUCloudAccessRoleCreate(ctx context.Context, d *schema.ResourceData
 	}
 
 	// Send the POST request
-	resp, err := client.POST("/v3/ou-cloud-access-role", post)
+	resp, err := client.POSTContext(ctx, "/v3/ou-cloud-access-role", post)
 	if err != nil {
-		return append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create OUCloudAccessRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err, post),
-		})
+		return append(diags, apiErrorDiagnostics(d, "Unable to create OUCloudAccessRole", err)...)
 	}
 
 	if resp.RecordID == 0 {
 		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create OUCloudAccessRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 	}
 
@@ -186,13 +186,14 @@ func resourceOUCloudAccessRoleRead(ctx context.Context, d *schema.ResourceData,
 	ID := d.Id()
 
 	resp := new(hc.OUCloudAccessRoleResponse)
-	err := client.GET(fmt.Sprintf("/v3/ou-cloud-access-role/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/ou-cloud-access-role/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read OUCloudAccessRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "OUCloudAccessRole not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read OUCloudAccessRole", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -214,11 +215,7 @@ func resourceOUCloudAccessRoleRead(ctx context.Context, d *schema.ResourceData,
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set OUCloudAccessRole",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set OUCloudAccessRole", err)...)
 			return diags
 		}
 	}
@@ -246,12 +243,8 @@ func resourceOUCloudAccessRoleUpdate(ctx context.Context, d *schema.ResourceData
 			WebAccess:           d.Get("web_access").(bool),
 		}
 
-		if err := client.PATCH(fmt.Sprintf("/v3/ou-cloud-access-role/%s", ID), req); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update OUCloudAccessRole",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+		if err := client.PATCHContext(ctx, fmt.Sprintf("/v3/ou-cloud-access-role/%s", ID), req); err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update OUCloudAccessRole", err)...)
 			return diags
 		}
 	}
@@ -294,23 +287,15 @@ func resourceOUCloudAccessRoleUpdate(ctx context.Context, d *schema.ResourceData
 		}
 
 		if addCarAssociation != (hc.OUCloudAccessRoleAssociationsAdd{}) {
-			if _, err := client.POST(fmt.Sprintf("/v3/ou-cloud-access-role/%s/association", ID), addCarAssociation); err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add associations on OUCloudAccessRole",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+			if _, err := client.POSTContext(ctx, fmt.Sprintf("/v3/ou-cloud-access-role/%s/association", ID), addCarAssociation); err != nil {
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add associations on OUCloudAccessRole", err)...)
 				return diags
 			}
 		}
 
 		if removeCarAssociation != (hc.OUCloudAccessRoleAssociationsRemove{}) {
-			if err := client.DELETE(fmt.Sprintf("/v3/ou-cloud-access-role/%s/association", ID), removeCarAssociation); err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove associations on OUCloudAccessRole",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+			if err := client.DELETEContext(ctx, fmt.Sprintf("/v3/ou-cloud-access-role/%s/association", ID), removeCarAssociation); err != nil {
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove associations on OUCloudAccessRole", err)...)
 				return diags
 			}
 		}
@@ -318,11 +303,7 @@ func resourceOUCloudAccessRoleUpdate(ctx context.Context, d *schema.ResourceData
 
 	if hasChanged {
 		if err := d.Set("last_updated", time.Now().Format(time.RFC850)); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to set last_updated",
-				Detail:   fmt.Sprintf("Error: %v", err),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to set last_updated", err)...)
 			return diags
 		}
 	}
@@ -336,14 +317,9 @@ func resourceOUCloudAccessRoleDelete(ctx context.Context, d *schema.ResourceData
 	ID := d.Id()
 
 	// Make the DELETE request using the client and context
-	err := client.DELETE(fmt.Sprintf("/v3/ou-cloud-access-role/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/ou-cloud-access-role/%s", ID), nil)
 	if err != nil {
-		// Add detailed diagnostic information on error
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete OUCloudAccessRole",
-			Detail:   fmt.Sprintf("Error deleting OUCloudAccessRole with ID %s: %v", ID, err),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete OUCloudAccessRole", err)...)
 		return diags
 	}
 
@@ -1,6 +1,7 @@
 package kion
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -9,26 +10,18 @@ import (
 )
 
 // OUChanges allows moving an OU if the parent ID changes and updating permissions.
-func OUChanges(client *hc.Client, d *schema.ResourceData, diags diag.Diagnostics, hasChanged int) (diag.Diagnostics, int) {
+func OUChanges(ctx context.Context, client *hc.Client, d *schema.ResourceData, diags diag.Diagnostics, hasChanged int) (diag.Diagnostics, int) {
 	// Handle OU move.
 	if d.HasChanges("parent_ou_id") {
 		hasChanged++
 		arrParentOUID, _, _, err := hc.AssociationChangedInt(d, "parent_ou_id")
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to determine changeset for ParentOU on OrganizationalUnit",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), d.Id()),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to determine changeset for ParentOU on OrganizationalUnit", err)...)
 			return diags, hasChanged
 		}
-		_, err = client.POST(fmt.Sprintf("/v2/ou/%s/move", d.Id()), arrParentOUID)
+		_, err = client.POSTContext(ctx, fmt.Sprintf("/v2/ou/%s/move", d.Id()), arrParentOUID)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to add update Parent OU for Organizational Unit",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), d.Id()),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to add update Parent OU for Organizational Unit", err)...)
 			return diags, hasChanged
 		}
 	}
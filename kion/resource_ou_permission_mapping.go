@@ -0,0 +1,283 @@
+package kion
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+func resourceOUPermissionMapping() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOUPermissionMappingCreate,
+		ReadContext:   resourceOUPermissionMappingRead,
+		UpdateContext: resourceOUPermissionMappingUpdate,
+		DeleteContext: resourceOUPermissionMappingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				if _, _, err := parsePermissionMappingID(d.Id(), "ou_id"); err != nil {
+					return nil, err
+				}
+				return readImportedState(ctx, d, m, resourceOUPermissionMappingRead)
+			},
+		},
+		CustomizeDiff: validateReferences(
+			referenceTo("ou_id", "", hc.ReferenceOU),
+			referenceTo("users", "id", hc.ReferenceUser),
+			referenceTo("user_groups", "id", hc.ReferenceUserGroup),
+		),
+		Description: "Manages the users and user groups that hold an app role on an OU. The resource is authoritative for the role: " +
+			"users and user groups that hold it on the OU but are not listed are removed. Import with the ID <ou_id>-<app_role_id>.",
+		Schema: map[string]*schema.Schema{
+			// Notice there is no 'id' field specified because it will be created.
+			"last_updated": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"app_role_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the app role, e.g. 1 for the owner role.",
+			},
+			"ou_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_groups": {
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The user groups that hold the app role on the OU.",
+			},
+			"users": {
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The users that hold the app role on the OU.",
+			},
+		},
+	}
+}
+
+// parsePermissionMappingID splits the ID of a permission mapping resource,
+// which has the form <parent>-<app_role_id>, e.g. <ou_id>-<app_role_id>.
+func parsePermissionMappingID(ID, parent string) (int, int, error) {
+	parts := strings.Split(ID, "-")
+	if len(parts) == 2 {
+		parentID, err := strconv.Atoi(parts[0])
+		if err == nil {
+			appRoleID, err := strconv.Atoi(parts[1])
+			if err == nil {
+				return parentID, appRoleID, nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid permission mapping ID %q, expected <%s>-<app_role_id>", ID, parent)
+}
+
+// readOUPermissionMapping returns the users and user groups that hold an app
+// role on an OU.
+func readOUPermissionMapping(ctx context.Context, client *hc.Client, ouID, appRoleID int) ([]int, []int, error) {
+	resp := new(hc.OUPermissionMappingListResponse)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/ou/%d/permission-mapping", ouID), resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userIDs := make([]int, 0)
+	userGroupIDs := make([]int, 0)
+	for _, item := range resp.Data {
+		if item.AppRoleID != appRoleID {
+			continue
+		}
+		if item.UserIds != nil {
+			userIDs = append(userIDs, *item.UserIds...)
+		}
+		if item.UserGroupIds != nil {
+			userGroupIDs = append(userGroupIDs, *item.UserGroupIds...)
+		}
+	}
+
+	return userIDs, userGroupIDs, nil
+}
+
+// changeOUPermissionMapping grants the app role to the users and user groups
+// to add and revokes it from the ones to remove.
+func changeOUPermissionMapping(ctx context.Context, client *hc.Client, ouID, appRoleID int, addUserIDs, addUserGroupIDs, removeUserIDs, removeUserGroupIDs []int) error {
+	if len(addUserIDs) > 0 || len(addUserGroupIDs) > 0 {
+		_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/ou/%d/permission-mapping", ouID), hc.OUPermissionAdd{
+			AppRoleID:         &appRoleID,
+			OwnerUserGroupIds: &addUserGroupIDs,
+			OwnerUserIds:      &addUserIDs,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(removeUserIDs) > 0 || len(removeUserGroupIDs) > 0 {
+		err := client.DELETEContext(ctx, fmt.Sprintf("/v3/ou/%d/permission-mapping", ouID), hc.OUPermissionRemove{
+			AppRoleID:         &appRoleID,
+			OwnerUserGroupIds: &removeUserGroupIDs,
+			OwnerUserIds:      &removeUserIDs,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceOUPermissionMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+
+	ouID := d.Get("ou_id").(int)
+	appRoleID := d.Get("app_role_id").(int)
+
+	// The resource is authoritative, so compare against the users and user
+	// groups that already hold the role rather than against an empty state.
+	currentUserIDs, currentUserGroupIDs, err := readOUPermissionMapping(ctx, client, ouID, appRoleID)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read OU permission mapping", err)...)
+		return diags
+	}
+
+	userIDs := *hc.FlattenGenericIDPointer(d, "users")
+	userGroupIDs := *hc.FlattenGenericIDPointer(d, "user_groups")
+	addUserIDs, removeUserIDs, _ := hc.DetermineAssociations(userIDs, currentUserIDs)
+	addUserGroupIDs, removeUserGroupIDs, _ := hc.DetermineAssociations(userGroupIDs, currentUserGroupIDs)
+
+	err = changeOUPermissionMapping(ctx, client, ouID, appRoleID, addUserIDs, addUserGroupIDs, removeUserIDs, removeUserGroupIDs)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create OU permission mapping", err)...)
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%d-%d", ouID, appRoleID))
+
+	return resourceOUPermissionMappingRead(ctx, d, m)
+}
+
+func resourceOUPermissionMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+	ID := d.Id()
+
+	ouID, appRoleID, err := parsePermissionMappingID(ID, "ou_id")
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read OU permission mapping", err)...)
+		return diags
+	}
+
+	userIDs, userGroupIDs, err := readOUPermissionMapping(ctx, client, ouID, appRoleID)
+	if err != nil {
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "OU not found, removing permission mapping from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read OU permission mapping", err)...)
+		return diags
+	}
+
+	data := make(map[string]interface{})
+	data["app_role_id"] = appRoleID
+	data["ou_id"] = ouID
+	data["user_groups"] = hc.InflateArrayOfIDs(userGroupIDs)
+	data["users"] = hc.InflateArrayOfIDs(userIDs)
+
+	for k, v := range data {
+		if err := d.Set(k, v); err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set OU permission mapping", err)...)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceOUPermissionMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+
+	ouID := d.Get("ou_id").(int)
+	appRoleID := d.Get("app_role_id").(int)
+
+	// Determine if the users or user groups have changed.
+	if d.HasChanges("user_groups", "users") {
+		addUserGroupIDs, removeUserGroupIDs, _, _ := hc.AssociationChanged(d, "user_groups")
+		addUserIDs, removeUserIDs, _, _ := hc.AssociationChanged(d, "users")
+
+		err := changeOUPermissionMapping(ctx, client, ouID, appRoleID, addUserIDs, addUserGroupIDs, removeUserIDs, removeUserGroupIDs)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update OU permission mapping", err)...)
+			return diags
+		}
+
+		if err := d.Set("last_updated", time.Now().Format(time.RFC850)); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to set last_updated",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+
+	return resourceOUPermissionMappingRead(ctx, d, m)
+}
+
+func resourceOUPermissionMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+
+	ouID := d.Get("ou_id").(int)
+	appRoleID := d.Get("app_role_id").(int)
+
+	userIDs, userGroupIDs, err := readOUPermissionMapping(ctx, client, ouID, appRoleID)
+	if err != nil {
+		if hc.IsNotFound(err) {
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read OU permission mapping", err)...)
+		return diags
+	}
+
+	err = changeOUPermissionMapping(ctx, client, ouID, appRoleID, nil, nil, userIDs, userGroupIDs)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete OU permission mapping", err)...)
+		return diags
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,51 @@
+package kion
+
+import (
+	"net/http"
+	"testing"
+
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePermissionMappingID(t *testing.T) {
+	ouID, appRoleID, err := parsePermissionMappingID("12-3", "ou_id")
+	assert.NoError(t, err)
+	assert.Equal(t, 12, ouID)
+	assert.Equal(t, 3, appRoleID)
+
+	for _, invalid := range []string{"12", "12-", "a-3", "12-3-4"} {
+		_, _, err := parsePermissionMappingID(invalid, "ou_id")
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestOUPermissionMappingCreate(t *testing.T) {
+	requests := make(map[string]hc.OUPermissionAdd)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			var body hc.OUPermissionAdd
+			readTestBody(t, r, &body)
+			requests[r.Method] = body
+			_, _ = w.Write([]byte(`{"status": 200}`))
+			return
+		}
+		// User 1 and group 7 already hold the role, user 2 holds another one.
+		_, _ = w.Write([]byte(`{"status": 200, "data": [
+			{"app_role_id": 3, "user_ids": [1], "user_groups_ids": [7]},
+			{"app_role_id": 4, "user_ids": [2], "user_groups_ids": []}
+		]}`))
+	})
+
+	d := testCreate(t, resourceOUPermissionMapping(), map[string]interface{}{
+		"ou_id":       12,
+		"app_role_id": 3,
+		"users":       []interface{}{map[string]interface{}{"id": 1}, map[string]interface{}{"id": 5}},
+	}, client)
+	assert.Equal(t, "12-3", d.Id())
+
+	assert.Equal(t, []int{5}, *requests[http.MethodPost].OwnerUserIds)
+	assert.Empty(t, *requests[http.MethodPost].OwnerUserGroupIds)
+	assert.Empty(t, *requests[http.MethodDelete].OwnerUserIds)
+	assert.Equal(t, []int{7}, *requests[http.MethodDelete].OwnerUserGroupIds)
+}
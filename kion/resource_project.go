@@ -2,28 +2,42 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
 )
 
 func resourceProject() *schema.Resource {
-	return &schema.Resource{
+	return withOwnerNames("owner_user_ids", "owner_user_group_ids", &schema.Resource{
 		CreateContext: resourceProjectCreate,
 		ReadContext:   resourceProjectRead,
 		UpdateContext: resourceProjectUpdate,
 		DeleteContext: resourceProjectDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceProjectRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceProjectRead)
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			requireKionVersionForAttribute("kion_project", "labels", hc.VersionLabels),
+			validateProjectFundingMode,
+			validateReferences(
+				referenceTo("ou_id", "", hc.ReferenceOU),
+				referenceTo("permission_scheme_id", "", hc.ReferencePermissionScheme),
+				referenceTo("owner_user_ids", "id", hc.ReferenceUser),
+				referenceTo("owner_user_group_ids", "id", hc.ReferenceUserGroup),
+				referenceTo("project_funding", "funding_source_id", hc.ReferenceFundingSource),
+				referenceTo("budget", "data.funding_source_id", hc.ReferenceFundingSource),
+				referenceTo("budget", "funding_source_ids", hc.ReferenceFundingSource),
+				labelReference("labels"),
+			),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -95,36 +109,35 @@ func resourceProject() *schema.Resource {
 						"amount": {
 							Type:     schema.TypeFloat,
 							Optional: true,
-							ForceNew: true, // Not allowed to be changed, forces new item if changed.
 						},
 						"funding_order": {
 							Type:     schema.TypeInt,
 							Optional: true,
-							ForceNew: true, // Not allowed to be changed, forces new item if changed.
 						},
 						"funding_source_id": {
 							Type:     schema.TypeInt,
 							Optional: true,
-							ForceNew: true, // Not allowed to be changed, forces new item if changed.
 						},
 						"start_datecode": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true, // Not allowed to be changed, forces new item if changed.
 						},
 						"end_datecode": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true, // Not allowed to be changed, forces new item if changed.
 						},
 					},
 				},
 				Type:     schema.TypeSet,
 				Optional: true,
+				Computed: true,
+				Description: "Funding allocations of the project. Changes are applied in place and the allocations are read back from Kion. " +
+					"When not set, the allocations are read but not managed, e.g. to manage them with kion_project_funding resources instead.",
 			},
 			"budget": {
-				Type:     schema.TypeSet,
-				Optional: true,
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Budgets created with the project. They are only sent when the project is created, use kion_project_budget to manage budgets that can be changed.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"amount": {
@@ -193,7 +206,7 @@ func resourceProject() *schema.Resource {
 				Description: "A map of labels to assign to the project. The labels must already exist in Kion.",
 			},
 		},
-	}
+	})
 }
 
 func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -214,25 +227,15 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 	projectCreateURLSuffix := "with-spend-plan"
 
 	// Get financial config settings
-	type FinancialConfig struct {
-		Data struct {
-			BudgetMode bool `json:"budget_mode"`
-		} `json:"data"`
-	}
-	var config FinancialConfig
-	err := client.GET("/v1/ct-config/financials-config", &config)
+	budgetMode, err := client.BudgetMode(ctx)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to retrieve financial config",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to retrieve financial config", err)...)
 		return diags
 	}
 
 	// Can't cast directly to []interface{}
 	// Must cast each element to map[string]interface{} & assign each value from the map to the POST object.
-	if config.Data.BudgetMode {
+	if budgetMode {
 		projectCreateURLSuffix = "with-budget"
 
 		post.Budget = make([]hc.BudgetCreate, len(d.Get("budget").(*schema.Set).List()))
@@ -285,19 +288,15 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 	}
 
-	resp, err := client.POST(fmt.Sprintf("/v3/project/%v", projectCreateURLSuffix), post)
+	resp, err := client.POSTContext(ctx, fmt.Sprintf("/v3/project/%v", projectCreateURLSuffix), post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Project",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create Project", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create Project",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -306,14 +305,10 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	if labels, ok := d.GetOk("labels"); ok && labels != nil {
 		ID := d.Id()
-		err = hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "project", ID)
+		err = hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "project", ID)
 
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update Project labels",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Project labels", err)...)
 			return diags
 		}
 	}
@@ -329,13 +324,14 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 	ID := d.Id()
 
 	resp := new(hc.ProjectResponse)
-	err := client.GET(fmt.Sprintf("/v3/project/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/project/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Project",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "Project not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Project", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -351,35 +347,42 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 	for k, v := range data {
 		err := d.Set(k, v) // Use assignment instead of short declaration
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set Project",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set Project", err)...)
+			return diags
+		}
+	}
+
+	// Funding allocations only exist when budget mode is off.
+	budgetMode, err := client.BudgetMode(ctx)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to retrieve financial config", err)...)
+		return diags
+	}
+	if !budgetMode {
+		funding, err := readProjectFunding(ctx, client, ID)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read Project funding", err)...)
+			return diags
+		}
+
+		if err := d.Set("project_funding", inflateProjectFunding(funding)); err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to set funding for Project", err)...)
 			return diags
 		}
 	}
 
 	// Fetch labels
-	labelData, err := hc.ReadResourceLabels(client, "project", ID)
+	labelData, err := hc.ReadResourceLabels(ctx, client, "project", ID)
 
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Project labels",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Project labels", err)...)
 		return diags
 	}
 
 	// Set labels
 	err = d.Set("labels", labelData)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set labels for Project",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to set labels for Project", err)...)
 	}
 
 	return diags
@@ -412,13 +415,9 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			PermissionSchemeID: d.Get("permission_scheme_id").(int),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/project/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/project/%s", ID), req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update Project",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Project", err)...)
 			return diags
 		}
 	}
@@ -434,32 +433,35 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			len(arrAddOwnerUserIds) > 0 ||
 			len(arrRemoveOwnerUserGroupIds) > 0 ||
 			len(arrRemoveOwnerUserIds) > 0 {
-			_, err := client.POST(fmt.Sprintf("/v1/project/%s/owner", ID), hc.ChangeOwners{
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v1/project/%s/owner", ID), hc.ChangeOwners{
 				OwnerUserGroupIds: &arrAddOwnerUserGroupIds,
 				OwnerUserIds:      &arrAddOwnerUserIds,
 			})
 			if err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to change owners on Project",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to change owners on Project", err)...)
 				return diags
 			}
 		}
 	}
 
+	if d.HasChanges("project_funding") {
+		hasChanged++
+
+		o, n := d.GetChange("project_funding")
+		err := changeProjectFunding(ctx, client, ID, o.(*schema.Set), n.(*schema.Set))
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Project funding", err)...)
+			return diags
+		}
+	}
+
 	if d.HasChanges("labels") {
 		hasChanged++
 
-		err := hc.PutAppLabelIDs(client, hc.FlattenAssociateLabels(d, "labels"), "project", ID)
+		err := hc.PutAppLabelIDs(ctx, client, hc.FlattenAssociateLabels(d, "labels"), "project", ID)
 
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update Project labels",
-				Detail:   fmt.Sprintf("Error: %v\nProject ID: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Project labels", err)...)
 			return diags
 		}
 	}
@@ -483,13 +485,9 @@ func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interf
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/project/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/project/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete Project",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete Project", err)...)
 		return diags
 	}
 
@@ -499,3 +497,106 @@ func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interf
 
 	return diags
 }
+
+// readProjectFunding returns the funding allocations of a project.
+func readProjectFunding(ctx context.Context, client *hc.Client, projectID string) ([]hc.ProjectFunding, error) {
+	resp := new(hc.ProjectFundingListResponse)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/project/%s/funding", projectID), resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// inflateProjectFunding converts funding allocations to project_funding
+// entries.
+func inflateProjectFunding(funding []hc.ProjectFunding) []interface{} {
+	arr := make([]interface{}, 0, len(funding))
+	for _, item := range funding {
+		arr = append(arr, map[string]interface{}{
+			"amount":            item.Amount,
+			"end_datecode":      item.EndDatecode,
+			"funding_order":     item.FundingOrder,
+			"funding_source_id": item.FundingSourceID,
+			"start_datecode":    item.StartDatecode,
+		})
+	}
+	return arr
+}
+
+// projectFundingKey identifies a funding allocation by its funding source and
+// period, so a changed amount or order updates the allocation in place.
+func projectFundingKey(fundingSourceID int, startDatecode, endDatecode string) string {
+	return fmt.Sprintf("%d/%s/%s", fundingSourceID, startDatecode, endDatecode)
+}
+
+// changeProjectFunding applies the difference between the old and new
+// project_funding entries using the project funding endpoints: entries whose
+// funding source and period still exist are updated, the others are removed
+// or added.
+func changeProjectFunding(ctx context.Context, client *hc.Client, projectID string, o, n *schema.Set) error {
+	ID, err := strconv.Atoi(projectID)
+	if err != nil {
+		return err
+	}
+
+	current, err := readProjectFunding(ctx, client, projectID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]int, len(current))
+	for _, item := range current {
+		existing[projectFundingKey(item.FundingSourceID, item.StartDatecode, item.EndDatecode)] = item.ID
+	}
+
+	removed := make(map[string]int)
+	for _, v := range o.Difference(n).List() {
+		item := v.(map[string]interface{})
+		key := projectFundingKey(item["funding_source_id"].(int), item["start_datecode"].(string), item["end_datecode"].(string))
+		if id, ok := existing[key]; ok {
+			removed[key] = id
+		}
+	}
+
+	for _, v := range n.Difference(o).List() {
+		item := v.(map[string]interface{})
+		key := projectFundingKey(item["funding_source_id"].(int), item["start_datecode"].(string), item["end_datecode"].(string))
+
+		if id, ok := existing[key]; ok {
+			delete(removed, key)
+			err := client.PATCHContext(ctx, fmt.Sprintf("/v3/project-funding/%d", id), hc.ProjectFundingUpdate{
+				FundingSourceID: item["funding_source_id"].(int),
+				Amount:          item["amount"].(float64),
+				StartDatecode:   item["start_datecode"].(string),
+				EndDatecode:     item["end_datecode"].(string),
+				FundingOrder:    item["funding_order"].(int),
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		_, err := client.POSTContext(ctx, "/v3/project-funding", hc.ProjectFundingCreate{
+			ProjectID:       ID,
+			FundingSourceID: item["funding_source_id"].(int),
+			Amount:          item["amount"].(float64),
+			StartDatecode:   item["start_datecode"].(string),
+			EndDatecode:     item["end_datecode"].(string),
+			FundingOrder:    item["funding_order"].(int),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, id := range removed {
+		err := client.DELETEContext(ctx, fmt.Sprintf("/v3/project-funding/%d", id), nil)
+		if err != nil && !hc.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,283 @@
+package kion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+func resourceProjectBudget() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectBudgetCreate,
+		ReadContext:   resourceProjectBudgetRead,
+		UpdateContext: resourceProjectBudgetUpdate,
+		DeleteContext: resourceProjectBudgetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				return readImportedState(ctx, d, m, resourceProjectBudgetRead)
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			requireBudgetMode("kion_project_budget", true),
+			validateReferences(
+				referenceTo("project_id", "", hc.ReferenceProject),
+				referenceTo("data", "funding_source_id", hc.ReferenceFundingSource),
+				referenceTo("funding_source_ids", "", hc.ReferenceFundingSource),
+			),
+		),
+		Description: "Manages a budget of a project. Requires budget mode to be enabled in the Kion financial settings.",
+		Schema: map[string]*schema.Schema{
+			// Notice there is no 'id' field specified because it will be created.
+			"last_updated": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"amount": {
+				Type: schema.TypeFloat,
+				Description: "Total amount for the budget. This is required if data is not specified. " +
+					"Budget entries are created between start_datecode and end_datecode (exclusive) with the amount evenly distributed across the months.",
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"amount", "data"},
+			},
+			"data": {
+				Type: schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datecode": {
+							Type:        schema.TypeString,
+							Description: "Year and month for the budget data entry (i.e 2023-01).",
+							Required:    true,
+						},
+						"amount": {
+							Type:        schema.TypeFloat,
+							Description: "Amount of the budget entry in dollars.",
+							Required:    true,
+						},
+						"funding_source_id": {
+							Type:        schema.TypeInt,
+							Description: "ID of funding source for the budget entry.",
+							Optional:    true,
+						},
+						"priority": {
+							Type:        schema.TypeInt,
+							Description: "Priority order of the budget entry. This is required if funding_source_id is specified",
+							Optional:    true,
+						},
+					},
+				},
+				Description: "The monthly entries of the budget. When only amount is specified, Kion creates the entries and they are read back.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"end_datecode": {
+				Type:        schema.TypeString,
+				Description: "Year and month the budget ends. This is an exclusive date.",
+				Required:    true,
+			},
+			"funding_source_ids": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "Funding source IDs to use when data is not specified. " +
+					"This value is ignored is data is specified. If specified, the amount is distributed evenly across months and funding sources. " +
+					"Funding sources will be processed in order from first to last. " +
+					"They are read back from the funding sources of the monthly entries, in priority order.",
+				Optional: true,
+				Computed: true,
+			},
+			"project_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true, // Not allowed to be changed, forces new item if changed.
+			},
+			"start_datecode": {
+				Type:        schema.TypeString,
+				Description: "Year and month the budget starts.",
+				Required:    true,
+			},
+		},
+	}
+}
+
+// flattenBudgetData converts the data entries of a budget for a request. Only
+// entries that were configured are sent, so Kion recalculates the entries of
+// a budget that is defined by its amount.
+func flattenBudgetData(d *schema.ResourceData) []hc.BudgetDataCreate {
+	arr := make([]hc.BudgetDataCreate, 0)
+	if !isConfigured(d, "data") {
+		return arr
+	}
+
+	for _, v := range d.Get("data").(*schema.Set).List() {
+		item := v.(map[string]interface{})
+		arr = append(arr, hc.BudgetDataCreate{
+			Datecode:        item["datecode"].(string),
+			Amount:          item["amount"].(float64),
+			FundingSourceID: item["funding_source_id"].(int),
+			Priority:        item["priority"].(int),
+		})
+	}
+
+	return arr
+}
+
+// budgetFundingSourceIDs returns the funding sources of the data entries of a
+// budget, ordered by the priority they are used in.
+func budgetFundingSourceIDs(entries []hc.BudgetDataCreate) []int {
+	sorted := make([]hc.BudgetDataCreate, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+
+	ids := make([]int, 0)
+	seen := make(map[int]bool)
+	for _, entry := range sorted {
+		if entry.FundingSourceID == 0 || seen[entry.FundingSourceID] {
+			continue
+		}
+		seen[entry.FundingSourceID] = true
+		ids = append(ids, entry.FundingSourceID)
+	}
+
+	return ids
+}
+
+func resourceProjectBudgetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+
+	post := hc.BudgetCreate{
+		ProjectID:        d.Get("project_id").(int),
+		StartDatecode:    d.Get("start_datecode").(string),
+		EndDatecode:      d.Get("end_datecode").(string),
+		Amount:           d.Get("amount").(float64),
+		FundingSourceIDs: hc.FlattenIntArrayPointer(d.Get("funding_source_ids").([]interface{})),
+		Data:             flattenBudgetData(d),
+	}
+
+	resp, err := client.POSTContext(ctx, "/v3/budget", post)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create Project budget", err)...)
+		return diags
+	} else if resp.RecordID == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create Project budget",
+			Detail:   "Kion returned an item ID of 0.",
+		})
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(resp.RecordID))
+
+	return resourceProjectBudgetRead(ctx, d, m)
+}
+
+func resourceProjectBudgetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+	ID := d.Id()
+
+	resp := new(hc.BudgetResponse)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/budget/%s", ID), resp)
+	if err != nil {
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "Project budget not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Project budget", err)...)
+		return diags
+	}
+	item := resp.Data
+
+	entries := make([]interface{}, 0, len(item.Data))
+	for _, entry := range item.Data {
+		entries = append(entries, map[string]interface{}{
+			"datecode":          entry.Datecode,
+			"amount":            entry.Amount,
+			"funding_source_id": entry.FundingSourceID,
+			"priority":          entry.Priority,
+		})
+	}
+
+	data := make(map[string]interface{})
+	data["amount"] = item.Amount
+	data["data"] = entries
+	data["end_datecode"] = item.EndDatecode
+	data["funding_source_ids"] = budgetFundingSourceIDs(item.Data)
+	data["project_id"] = item.ProjectID
+	data["start_datecode"] = item.StartDatecode
+
+	for k, v := range data {
+		if err := d.Set(k, v); err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set Project budget", err)...)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceProjectBudgetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+	ID := d.Id()
+
+	if d.HasChanges("amount", "data", "end_datecode", "funding_source_ids", "start_datecode") {
+		req := hc.BudgetUpdate{
+			StartDatecode:    d.Get("start_datecode").(string),
+			EndDatecode:      d.Get("end_datecode").(string),
+			Amount:           d.Get("amount").(float64),
+			FundingSourceIDs: hc.FlattenIntArrayPointer(d.Get("funding_source_ids").([]interface{})),
+			Data:             flattenBudgetData(d),
+		}
+
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/budget/%s", ID), req)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Project budget", err)...)
+			return diags
+		}
+
+		if err := d.Set("last_updated", time.Now().Format(time.RFC850)); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to set last_updated",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+
+	return resourceProjectBudgetRead(ctx, d, m)
+}
+
+func resourceProjectBudgetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+	ID := d.Id()
+
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/budget/%s", ID), nil)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete Project budget", err)...)
+		return diags
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
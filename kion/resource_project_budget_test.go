@@ -0,0 +1,54 @@
+package kion
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectBudget(t *testing.T) {
+	var post hc.BudgetCreate
+	var body string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/budget":
+			body = readTestBody(t, r, &post)
+			_, _ = w.Write([]byte(`{"status": 201, "record_id": 7}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/budget/7":
+			_, _ = w.Write([]byte(`{"status": 200, "data": {
+				"id": 7, "project_id": 12, "start_datecode": "2024-01", "end_datecode": "2024-03", "amount": 200,
+				"data": [
+					{"datecode": "2024-01", "amount": 60, "funding_source_id": 5, "priority": 2},
+					{"datecode": "2024-01", "amount": 40, "funding_source_id": 3, "priority": 1},
+					{"datecode": "2024-02", "amount": 100, "funding_source_id": 3, "priority": 1}
+				]
+			}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	d := testCreate(t, resourceProjectBudget(), map[string]interface{}{
+		"project_id":         12,
+		"start_datecode":     "2024-01",
+		"end_datecode":       "2024-03",
+		"amount":             200.0,
+		"funding_source_ids": []interface{}{3},
+	}, client)
+	assert.Equal(t, "7", d.Id())
+	assert.Equal(t, 12, post.ProjectID)
+	assert.Equal(t, 200.0, post.Amount)
+	assert.Equal(t, []int{3}, *post.FundingSourceIDs)
+	assert.NotContains(t, body, "ou_id")
+
+	// The monthly entries Kion created are read back.
+	assert.Equal(t, 3, d.Get("data").(*schema.Set).Len())
+	assert.Equal(t, 200.0, d.Get("amount"))
+
+	// The funding sources are read back in priority order, so changes made
+	// in Kion show up as drift.
+	assert.Equal(t, []interface{}{3, 5}, d.Get("funding_source_ids"))
+}
@@ -2,11 +2,11 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
@@ -20,10 +20,14 @@ func resourceProjectCloudAccessRole() *schema.Resource {
 		DeleteContext: resourceProjectCloudAccessRoleDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceProjectCloudAccessRoleRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceProjectCloudAccessRoleRead)
 			},
 		},
+		CustomizeDiff: validateReferences(
+			referenceTo("project_id", "", hc.ReferenceProject),
+			referenceTo("users", "id", hc.ReferenceUser),
+			referenceTo("user_groups", "id", hc.ReferenceUserGroup),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -181,20 +185,16 @@ func resourceProjectCloudAccessRoleCreate(ctx context.Context, d *schema.Resourc
 	}
 
 	// Send the POST request
-	resp, err := client.POST("/v3/project-cloud-access-role", post)
+	resp, err := client.POSTContext(ctx, "/v3/project-cloud-access-role", post)
 	if err != nil {
-		return append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create ProjectCloudAccessRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err, post),
-		})
+		return append(diags, apiErrorDiagnostics(d, "Unable to create ProjectCloudAccessRole", err)...)
 	}
 
 	if resp.RecordID == 0 {
 		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create ProjectCloudAccessRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 	}
 
@@ -215,13 +215,14 @@ func resourceProjectCloudAccessRoleRead(ctx context.Context, d *schema.ResourceD
 	ID := d.Id()
 
 	resp := new(hc.ProjectCloudAccessRoleResponse)
-	err := client.GET(fmt.Sprintf("/v3/project-cloud-access-role/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/project-cloud-access-role/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read ProjectCloudAccessRole",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "ProjectCloudAccessRole not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read ProjectCloudAccessRole", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -247,11 +248,7 @@ func resourceProjectCloudAccessRoleRead(ctx context.Context, d *schema.ResourceD
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set ProjectCloudAccessRole",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set ProjectCloudAccessRole", err)...)
 			return diags
 		}
 	}
@@ -278,12 +275,8 @@ func resourceProjectCloudAccessRoleUpdate(ctx context.Context, d *schema.Resourc
 			WebAccess:           d.Get("web_access").(bool),
 		}
 
-		if err := client.PATCH(fmt.Sprintf("/v3/project-cloud-access-role/%s", ID), req); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update ProjectCloudAccessRole",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+		if err := client.PATCHContext(ctx, fmt.Sprintf("/v3/project-cloud-access-role/%s", ID), req); err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update ProjectCloudAccessRole", err)...)
 			return diags
 		}
 	}
@@ -331,23 +324,15 @@ func resourceProjectCloudAccessRoleUpdate(ctx context.Context, d *schema.Resourc
 		}
 
 		if addCarAssociation != (hc.ProjectCloudAccessRoleAssociationsAdd{}) {
-			if _, err := client.POST(fmt.Sprintf("/v3/project-cloud-access-role/%s/association", ID), addCarAssociation); err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to add associations on ProjectCloudAccessRole",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+			if _, err := client.POSTContext(ctx, fmt.Sprintf("/v3/project-cloud-access-role/%s/association", ID), addCarAssociation); err != nil {
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add associations on ProjectCloudAccessRole", err)...)
 				return diags
 			}
 		}
 
 		if removeCarAssociation != (hc.ProjectCloudAccessRoleAssociationsRemove{}) {
-			if err := client.DELETE(fmt.Sprintf("/v3/project-cloud-access-role/%s/association", ID), removeCarAssociation); err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Unable to remove associations on ProjectCloudAccessRole",
-					Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-				})
+			if err := client.DELETEContext(ctx, fmt.Sprintf("/v3/project-cloud-access-role/%s/association", ID), removeCarAssociation); err != nil {
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove associations on ProjectCloudAccessRole", err)...)
 				return diags
 			}
 		}
@@ -355,11 +340,7 @@ func resourceProjectCloudAccessRoleUpdate(ctx context.Context, d *schema.Resourc
 
 	if hasChanged {
 		if err := d.Set("last_updated", time.Now().Format(time.RFC850)); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to set last_updated",
-				Detail:   fmt.Sprintf("Error: %v", err),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to set last_updated", err)...)
 			return diags
 		}
 	}
@@ -373,14 +354,9 @@ func resourceProjectCloudAccessRoleDelete(ctx context.Context, d *schema.Resourc
 	ID := d.Id()
 
 	// Make the DELETE request using the client and context
-	err := client.DELETE(fmt.Sprintf("/v3/project-cloud-access-role/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/project-cloud-access-role/%s", ID), nil)
 	if err != nil {
-		// Add detailed diagnostic information on error
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete ProjectCloudAccessRole",
-			Detail:   fmt.Sprintf("Error deleting ProjectCloudAccessRole with ID %s: %v", ID, err),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete ProjectCloudAccessRole", err)...)
 		return diags
 	}
 
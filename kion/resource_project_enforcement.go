@@ -3,7 +3,6 @@ package kion
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 
@@ -26,6 +25,12 @@ func resourceProjectEnforcement() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: validateReferences(
+			referenceTo("project_id", "", hc.ReferenceProject),
+			referenceTo("cloud_rule_id", "", hc.ReferenceCloudRule),
+			referenceTo("user_ids", "", hc.ReferenceUser),
+			referenceTo("user_group_ids", "", hc.ReferenceUserGroup),
+		),
 		Schema: map[string]*schema.Schema{
 			"description": {
 				Type:        schema.TypeString,
@@ -167,19 +172,15 @@ func resourceProjectEnforcementCreate(ctx context.Context, d *schema.ResourceDat
 	projectEnforcementURL := fmt.Sprintf("/v3/project/%d/enforcement", projectIDInt)
 
 	// Send the create request
-	resp, err := client.POST(projectEnforcementURL, post)
+	resp, err := client.POSTContext(ctx, projectEnforcementURL, post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to Create Project Enforcement",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to Create Project Enforcement", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Failed Project Enforcement Creation",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -205,8 +206,13 @@ func resourceProjectEnforcementRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	resp := new(hc.ProjectEnforcementResponse)
-	err = client.GET(fmt.Sprintf("/v3/project/%d/enforcement", projectID), resp)
+	err = client.GETContext(ctx, fmt.Sprintf("/v3/project/%d/enforcement", projectID), resp)
 	if err != nil {
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "Project of ProjectEnforcement not found, removing from state", map[string]interface{}{"id": enforcementID, "project_id": projectID})
+			d.SetId("")
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
@@ -233,7 +239,8 @@ func resourceProjectEnforcementRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if !found {
-		return diag.Errorf("Enforcement ID %d not found under Project ID %d", enforcementIDInt, projectID)
+		tflog.Warn(ctx, "ProjectEnforcement not found, removing from state", map[string]interface{}{"id": enforcementID, "project_id": projectID})
+		d.SetId("")
 	}
 
 	return diags
@@ -267,9 +274,9 @@ func AddProjectEnforcementUsers(ctx context.Context, d *schema.ResourceData, m i
 	}
 
 	endpoint := fmt.Sprintf("/v3/project/%d/enforcement/%s/user", projectIDInt, enforcementID)
-	_, err := client.POST(endpoint, req)
+	_, err := client.POSTContext(ctx, endpoint, req)
 	if err != nil {
-		return diag.Errorf("Error adding users/user groups in Project Enforcement: %v", err)
+		return apiErrorDiagnostics(d, "Error adding users/user groups in Project Enforcement", err)
 	}
 
 	return diags
@@ -312,9 +319,9 @@ func RemoveProjectEnforcementUsers(ctx context.Context, d *schema.ResourceData,
 	}
 
 	endpoint := fmt.Sprintf("/v3/project/%d/enforcement/%s/user", projectIDInt, enforcementID)
-	err := client.DELETE(endpoint, req)
+	err := client.DELETEContext(ctx, endpoint, req)
 	if err != nil {
-		return diag.Errorf("Error removing users/user groups in Project Enforcement: %v", err)
+		return apiErrorDiagnostics(d, "Error removing users/user groups in Project Enforcement", err)
 	}
 
 	return diags
@@ -353,9 +360,9 @@ func resourceProjectEnforcementUpdate(ctx context.Context, d *schema.ResourceDat
 
 		// Send the update request
 		endpoint := fmt.Sprintf("/v3/project/%d/enforcement/%s", projectIDInt, enforcementID)
-		err := client.PATCH(endpoint, req)
+		err := client.PATCHContext(ctx, endpoint, req)
 		if err != nil {
-			return diag.Errorf("Unable to update Project Enforcement: %v", err)
+			return apiErrorDiagnostics(d, "Unable to update Project Enforcement", err)
 		}
 	}
 
@@ -399,13 +406,9 @@ func resourceProjectEnforcementDelete(ctx context.Context, d *schema.ResourceDat
 
 	// Preparing the endpoint URL
 	endpoint := fmt.Sprintf("/v3/project/%d/enforcement/%s", projectIDInt, enforcementID)
-	err := client.DELETE(endpoint, nil)
+	err := client.DELETEContext(ctx, endpoint, nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete Project Enforcement",
-			Detail:   fmt.Sprintf("Error: %v when attempting to delete the enforcement with ID: %s", err.Error(), enforcementID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete Project Enforcement", err)...)
 		return diags
 	}
 
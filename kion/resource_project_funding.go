@@ -0,0 +1,194 @@
+package kion
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+func resourceProjectFunding() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectFundingCreate,
+		ReadContext:   resourceProjectFundingRead,
+		UpdateContext: resourceProjectFundingUpdate,
+		DeleteContext: resourceProjectFundingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				return readImportedState(ctx, d, m, resourceProjectFundingRead)
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			requireBudgetMode("kion_project_funding", false),
+			validateReferences(
+				referenceTo("project_id", "", hc.ReferenceProject),
+				referenceTo("funding_source_id", "", hc.ReferenceFundingSource),
+			),
+		),
+		Description: "Manages a funding allocation of a project. Not supported when budget mode is enabled in the Kion financial settings, " +
+			"use kion_project_budget instead. Leave the project_funding attribute of kion_project unset for projects funded with this resource.",
+		Schema: map[string]*schema.Schema{
+			// Notice there is no 'id' field specified because it will be created.
+			"last_updated": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"amount": {
+				Type:        schema.TypeFloat,
+				Description: "Amount allocated from the funding source in dollars.",
+				Required:    true,
+			},
+			"end_datecode": {
+				Type:        schema.TypeString,
+				Description: "Year and month the allocation ends (i.e 2024-12).",
+				Required:    true,
+			},
+			"funding_order": {
+				Type:        schema.TypeInt,
+				Description: "Order in which the funding of the project is used. Kion assigns one when not specified.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"funding_source_id": {
+				Type:        schema.TypeInt,
+				Description: "ID of the funding source to allocate from.",
+				Required:    true,
+			},
+			"project_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true, // Not allowed to be changed, forces new item if changed.
+			},
+			"start_datecode": {
+				Type:        schema.TypeString,
+				Description: "Year and month the allocation starts (i.e 2024-01).",
+				Required:    true,
+			},
+		},
+	}
+}
+
+func resourceProjectFundingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+
+	post := hc.ProjectFundingCreate{
+		ProjectID:       d.Get("project_id").(int),
+		FundingSourceID: d.Get("funding_source_id").(int),
+		Amount:          d.Get("amount").(float64),
+		StartDatecode:   d.Get("start_datecode").(string),
+		EndDatecode:     d.Get("end_datecode").(string),
+		FundingOrder:    d.Get("funding_order").(int),
+	}
+
+	resp, err := client.POSTContext(ctx, "/v3/project-funding", post)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create Project funding", err)...)
+		return diags
+	} else if resp.RecordID == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create Project funding",
+			Detail:   "Kion returned an item ID of 0.",
+		})
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(resp.RecordID))
+
+	return resourceProjectFundingRead(ctx, d, m)
+}
+
+func resourceProjectFundingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+	ID := d.Id()
+
+	resp := new(hc.ProjectFundingResponse)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/project-funding/%s", ID), resp)
+	if err != nil {
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "Project funding not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Project funding", err)...)
+		return diags
+	}
+	item := resp.Data
+
+	data := make(map[string]interface{})
+	data["amount"] = item.Amount
+	data["end_datecode"] = item.EndDatecode
+	data["funding_order"] = item.FundingOrder
+	data["funding_source_id"] = item.FundingSourceID
+	data["project_id"] = item.ProjectID
+	data["start_datecode"] = item.StartDatecode
+
+	for k, v := range data {
+		if err := d.Set(k, v); err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set Project funding", err)...)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceProjectFundingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+	ID := d.Id()
+
+	if d.HasChanges("amount", "end_datecode", "funding_order", "funding_source_id", "start_datecode") {
+		req := hc.ProjectFundingUpdate{
+			FundingSourceID: d.Get("funding_source_id").(int),
+			Amount:          d.Get("amount").(float64),
+			StartDatecode:   d.Get("start_datecode").(string),
+			EndDatecode:     d.Get("end_datecode").(string),
+			FundingOrder:    d.Get("funding_order").(int),
+		}
+
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/project-funding/%s", ID), req)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Project funding", err)...)
+			return diags
+		}
+
+		if err := d.Set("last_updated", time.Now().Format(time.RFC850)); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to set last_updated",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+
+	return resourceProjectFundingRead(ctx, d, m)
+}
+
+func resourceProjectFundingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+	ID := d.Id()
+
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/project-funding/%s", ID), nil)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete Project funding", err)...)
+		return diags
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
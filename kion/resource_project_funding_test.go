@@ -0,0 +1,122 @@
+package kion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectFunding(t *testing.T) {
+	var post hc.ProjectFundingCreate
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/project-funding":
+			readTestBody(t, r, &post)
+			_, _ = w.Write([]byte(`{"status": 201, "record_id": 4}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/project-funding/4":
+			_, _ = w.Write([]byte(`{"status": 200, "data": {
+				"id": 4, "project_id": 12, "funding_source_id": 3, "amount": 500,
+				"start_datecode": "2024-01", "end_datecode": "2024-12", "funding_order": 1
+			}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	d := testCreate(t, resourceProjectFunding(), map[string]interface{}{
+		"project_id":        12,
+		"funding_source_id": 3,
+		"amount":            500.0,
+		"start_datecode":    "2024-01",
+		"end_datecode":      "2024-12",
+	}, client)
+	assert.Equal(t, "4", d.Id())
+	assert.Equal(t, 12, post.ProjectID)
+	assert.Equal(t, 3, post.FundingSourceID)
+
+	// The order Kion assigned is read back.
+	assert.Equal(t, 1, d.Get("funding_order"))
+}
+
+func TestChangeProjectFunding(t *testing.T) {
+	var requests []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/project/12/funding":
+			_, _ = w.Write([]byte(`{"status": 200, "data": [
+				{"id": 4, "project_id": 12, "funding_source_id": 3, "amount": 500, "start_datecode": "2024-01", "end_datecode": "2024-12", "funding_order": 1},
+				{"id": 5, "project_id": 12, "funding_source_id": 6, "amount": 100, "start_datecode": "2024-01", "end_datecode": "2024-12", "funding_order": 2}
+			]}`))
+		case r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"status": 201, "record_id": 8}`))
+		default:
+			_, _ = w.Write([]byte(`{"status": 200}`))
+		}
+	})
+
+	fundingSchema := resourceProject().Schema["project_funding"]
+	set := func(entries ...map[string]interface{}) *schema.Set {
+		items := make([]interface{}, 0, len(entries))
+		for _, entry := range entries {
+			items = append(items, entry)
+		}
+		return schema.NewSet(schema.HashResource(fundingSchema.Elem.(*schema.Resource)), items)
+	}
+	entry := func(fundingSourceID int, amount float64, startDatecode string, order int) map[string]interface{} {
+		return map[string]interface{}{
+			"amount":            amount,
+			"end_datecode":      "2024-12",
+			"funding_order":     order,
+			"funding_source_id": fundingSourceID,
+			"start_datecode":    startDatecode,
+		}
+	}
+
+	o := set(entry(3, 500, "2024-01", 1), entry(6, 100, "2024-01", 2))
+	n := set(entry(3, 750, "2024-01", 1), entry(9, 200, "2024-06", 2))
+
+	err := changeProjectFunding(context.Background(), client, "12", o, n)
+	assert.NoError(t, err)
+
+	// The changed amount is updated in place, the new allocation is added and
+	// the removed one is deleted, so the project itself is left alone.
+	assert.ElementsMatch(t, []string{
+		"GET /api/v3/project/12/funding",
+		"PATCH /api/v3/project-funding/4",
+		"POST /api/v3/project-funding",
+		"DELETE /api/v3/project-funding/5",
+	}, requests)
+}
+
+func TestProjectReadFunding(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/project/12":
+			_, _ = w.Write([]byte(`{"status": 200, "data": {"id": 12, "name": "test", "ou_id": 1}}`))
+		case "/api/v1/ct-config/financials-config":
+			_, _ = w.Write([]byte(`{"status": 200, "data": {"budget_mode": false}}`))
+		case "/api/v3/project/12/funding":
+			_, _ = w.Write([]byte(`{"status": 200, "data": [
+				{"id": 4, "project_id": 12, "funding_source_id": 3, "amount": 500, "start_datecode": "2024-01", "end_datecode": "2024-12", "funding_order": 1}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"status": 200, "data": []}`))
+		}
+	})
+
+	// The funding is read even when project_funding is not set, e.g. after
+	// an import.
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{})
+	d.SetId("12")
+	diags := resourceProjectRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), diags)
+
+	funding := d.Get("project_funding").(*schema.Set).List()
+	assert.Len(t, funding, 1)
+	assert.Equal(t, 3, funding[0].(map[string]interface{})["funding_source_id"])
+}
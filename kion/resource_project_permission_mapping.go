@@ -0,0 +1,230 @@
+package kion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+func resourceProjectPermissionMapping() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectPermissionMappingCreate,
+		ReadContext:   resourceProjectPermissionMappingRead,
+		UpdateContext: resourceProjectPermissionMappingUpdate,
+		DeleteContext: resourceProjectPermissionMappingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				if _, _, err := parsePermissionMappingID(d.Id(), "project_id"); err != nil {
+					return nil, err
+				}
+				return readImportedState(ctx, d, m, resourceProjectPermissionMappingRead)
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			rejectProjectOwnerRole,
+			validateReferences(
+				referenceTo("project_id", "", hc.ReferenceProject),
+				referenceTo("users", "id", hc.ReferenceUser),
+				referenceTo("user_groups", "id", hc.ReferenceUserGroup),
+			),
+		),
+		Description: "Manages the users and user groups that hold an app role on a project. The resource is authoritative for the role: " +
+			"users and user groups that hold it on the project but are not listed are removed. The owner role (app role 1) is not supported, " +
+			"the owners of a project are managed by the owner attributes of kion_project. Import with the ID <project_id>-<app_role_id>.",
+		Schema: map[string]*schema.Schema{
+			// Notice there is no 'id' field specified because it will be created.
+			"last_updated": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"app_role_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the app role to assign. Can't be the owner role, 1.",
+			},
+			"project_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_groups": {
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The user groups that hold the app role on the project.",
+			},
+			"users": {
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The users that hold the app role on the project.",
+			},
+		},
+	}
+}
+
+// rejectProjectOwnerRole fails the plan when the owner role is assigned, the
+// owners of a project are managed by kion_project, which would remove the
+// users and user groups added here on its next apply.
+func rejectProjectOwnerRole(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("app_role_id").(int) == 1 {
+		return fmt.Errorf("app_role_id 1 is the project owner role, set owner_user_ids or owner_user_group_ids on kion_project instead")
+	}
+	return nil
+}
+
+// patchProjectPermissionMapping replaces the users and user groups that hold
+// an app role on a project.
+func patchProjectPermissionMapping(ctx context.Context, client *hc.Client, projectID, appRoleID int, userIDs, userGroupIDs *[]int) error {
+	patch := []hc.ProjectPermissionMapping{
+		{
+			AppRoleID:    appRoleID,
+			UserGroupIds: userGroupIDs,
+			UserIds:      userIDs,
+		},
+	}
+
+	return client.PATCHContext(ctx, fmt.Sprintf("/v3/project/%d/permission-mapping", projectID), patch)
+}
+
+func resourceProjectPermissionMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+
+	projectID := d.Get("project_id").(int)
+	appRoleID := d.Get("app_role_id").(int)
+
+	err := patchProjectPermissionMapping(ctx, client, projectID, appRoleID,
+		hc.FlattenGenericIDPointer(d, "users"),
+		hc.FlattenGenericIDPointer(d, "user_groups"))
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create Project permission mapping", err)...)
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%d-%d", projectID, appRoleID))
+
+	return resourceProjectPermissionMappingRead(ctx, d, m)
+}
+
+func resourceProjectPermissionMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+	ID := d.Id()
+
+	projectID, appRoleID, err := parsePermissionMappingID(ID, "project_id")
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Project permission mapping", err)...)
+		return diags
+	}
+
+	resp := new(hc.ProjectUserMappingListResponse)
+	err = client.GETContext(ctx, fmt.Sprintf("/v3/project/%d/permission-mapping", projectID), resp)
+	if err != nil {
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "Project not found, removing permission mapping from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Project permission mapping", err)...)
+		return diags
+	}
+
+	data := make(map[string]interface{})
+	data["app_role_id"] = appRoleID
+	data["project_id"] = projectID
+	data["user_groups"] = make([]interface{}, 0)
+	data["users"] = make([]interface{}, 0)
+
+	for _, permissionItem := range resp.Data {
+		if permissionItem.AppRoleId == appRoleID {
+			if permissionItem.UserGroupIds != nil {
+				data["user_groups"] = hc.InflateArrayOfIDs(*permissionItem.UserGroupIds)
+			}
+			if permissionItem.UserIds != nil {
+				data["users"] = hc.InflateArrayOfIDs(*permissionItem.UserIds)
+			}
+		}
+	}
+
+	for k, v := range data {
+		if err := d.Set(k, v); err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set Project permission mapping", err)...)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceProjectPermissionMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+
+	// Determine if the users or user groups have changed.
+	if d.HasChanges("user_groups", "users") {
+		arrAddUserGroupIds, arrRemoveUserGroupIds, _, _ := hc.AssociationChanged(d, "user_groups")
+		arrAddUserIds, arrRemoveUserIds, _, _ := hc.AssociationChanged(d, "users")
+
+		if len(arrAddUserGroupIds) > 0 || len(arrAddUserIds) > 0 || len(arrRemoveUserGroupIds) > 0 || len(arrRemoveUserIds) > 0 {
+			err := patchProjectPermissionMapping(ctx, client, d.Get("project_id").(int), d.Get("app_role_id").(int),
+				hc.FlattenGenericIDPointer(d, "users"),
+				hc.FlattenGenericIDPointer(d, "user_groups"))
+			if err != nil {
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to update Project permission mapping", err)...)
+				return diags
+			}
+		}
+
+		if err := d.Set("last_updated", time.Now().Format(time.RFC850)); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to set last_updated",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+
+	return resourceProjectPermissionMappingRead(ctx, d, m)
+}
+
+func resourceProjectPermissionMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+
+	empty := make([]int, 0)
+	err := patchProjectPermissionMapping(ctx, client, d.Get("project_id").(int), d.Get("app_role_id").(int), &empty, &empty)
+	if err != nil && !hc.IsNotFound(err) {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete Project permission mapping", err)...)
+		return diags
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
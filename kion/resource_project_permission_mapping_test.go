@@ -0,0 +1,52 @@
+package kion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectPermissionMapping(t *testing.T) {
+	var patch []hc.ProjectPermissionMapping
+	mapping := `[{"app_role_id": 4, "user_ids": [1, 5], "user_groups_ids": []}]`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/project/12/permission-mapping", r.URL.Path)
+		if r.Method == http.MethodPatch {
+			readTestBody(t, r, &patch)
+			_, _ = w.Write([]byte(`{"status": 200}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": 200, "data": ` + mapping + `}`))
+	})
+
+	d := testCreate(t, resourceProjectPermissionMapping(), map[string]interface{}{
+		"project_id":  12,
+		"app_role_id": 4,
+		"users":       []interface{}{map[string]interface{}{"id": 1}, map[string]interface{}{"id": 5}},
+	}, client)
+	assert.Equal(t, "12-4", d.Id())
+	assert.Len(t, patch, 1)
+	assert.Equal(t, 4, patch[0].AppRoleID)
+	assert.ElementsMatch(t, []int{1, 5}, *patch[0].UserIds)
+
+	// Changes made outside of Terraform are read back.
+	mapping = `[{"app_role_id": 4, "user_ids": [1], "user_groups_ids": [9]}, {"app_role_id": 1, "user_ids": [2]}]`
+	diags := resourceProjectPermissionMappingRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []int{1}, *hc.FlattenGenericIDPointer(d, "users"))
+	assert.Equal(t, []int{9}, *hc.FlattenGenericIDPointer(d, "user_groups"))
+}
+
+func TestProjectPermissionMappingOwnerRole(t *testing.T) {
+	// The owners of a project are managed by kion_project.
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":  12,
+		"app_role_id": 1,
+	})
+	_, err := resourceProjectPermissionMapping().SimpleDiff(context.Background(), nil, config, nil)
+	assert.ErrorContains(t, err, "owner role")
+}
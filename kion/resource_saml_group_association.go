@@ -2,11 +2,11 @@ package kion
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
@@ -20,10 +20,12 @@ func resourceSamlGroupAssociation() *schema.Resource {
 		DeleteContext: resourceSamlGroupAssociationDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-				resourceSamlGroupAssociationRead(ctx, d, m)
-				return []*schema.ResourceData{d}, nil
+				return readImportedState(ctx, d, m, resourceSamlGroupAssociationRead)
 			},
 		},
+		CustomizeDiff: validateReferences(
+			referenceTo("user_group_id", "", hc.ReferenceUserGroup),
+		),
 		Schema: map[string]*schema.Schema{
 			// Notice there is no 'id' field specified because it will be created.
 			"last_updated": {
@@ -76,19 +78,15 @@ func resourceSamlGroupAssociationCreate(ctx context.Context, d *schema.ResourceD
 		UserGroupID:    d.Get("user_group_id").(int),
 	}
 
-	resp, err := client.POST("/v3/idms/group-association", post)
+	resp, err := client.POSTContext(ctx, "/v3/idms/group-association", post)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create SamlGroupAssociation",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), post),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create SamlGroupAssociation", err)...)
 		return diags
 	} else if resp.RecordID == 0 {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create SamlGroupAssociation",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", errors.New("received item ID of 0"), post),
+			Detail:   "Kion returned an item ID of 0.",
 		})
 		return diags
 	}
@@ -106,13 +104,14 @@ func resourceSamlGroupAssociationRead(ctx context.Context, d *schema.ResourceDat
 	ID := d.Id()
 
 	resp := new(hc.GroupAssociationResponse)
-	err := client.GET(fmt.Sprintf("/v3/idms/group-association/%s", ID), resp)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/idms/group-association/%s", ID), resp)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read SamlGroupAssociation",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "SamlGroupAssociation not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read SamlGroupAssociation", err)...)
 		return diags
 	}
 	item := resp.Data
@@ -127,11 +126,7 @@ func resourceSamlGroupAssociationRead(ctx context.Context, d *schema.ResourceDat
 
 	for k, v := range data {
 		if err := d.Set(k, v); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to read and set SamlGroupAssociation",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set SamlGroupAssociation", err)...)
 			return diags
 		}
 	}
@@ -162,13 +157,9 @@ func resourceSamlGroupAssociationUpdate(ctx context.Context, d *schema.ResourceD
 			UserGroupID:    d.Get("user_group_id").(int),
 		}
 
-		err := client.PATCH(fmt.Sprintf("/v3/idms/group-association/%s", ID), req)
+		err := client.PATCHContext(ctx, fmt.Sprintf("/v3/idms/group-association/%s", ID), req)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update SamlGroupAssociation",
-				Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-			})
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update SamlGroupAssociation", err)...)
 			return diags
 		}
 	}
@@ -192,13 +183,9 @@ func resourceSamlGroupAssociationDelete(ctx context.Context, d *schema.ResourceD
 	client := m.(*hc.Client)
 	ID := d.Id()
 
-	err := client.DELETE(fmt.Sprintf("/v3/idms/group-association/%s", ID), nil)
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/idms/group-association/%s", ID), nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete SamlGroupAssociation",
-			Detail:   fmt.Sprintf("Error: %v\nItem: %v", err.Error(), ID),
-		})
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete SamlGroupAssociation", err)...)
 		return diags
 	}
 
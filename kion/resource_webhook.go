@@ -0,0 +1,362 @@
+package kion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+)
+
+func resourceWebhook() *schema.Resource {
+	return withOwnerNames("owner_users", "owner_user_groups", &schema.Resource{
+		CreateContext: resourceWebhookCreate,
+		ReadContext:   resourceWebhookRead,
+		UpdateContext: resourceWebhookUpdate,
+		DeleteContext: resourceWebhookDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				return readImportedState(ctx, d, m, resourceWebhookRead)
+			},
+		},
+		CustomizeDiff: validateReferences(
+			referenceTo("owner_users", "id", hc.ReferenceUser),
+			referenceTo("owner_user_groups", "id", hc.ReferenceUserGroup),
+		),
+		Description: "Manages a webhook that Kion calls, e.g. before or after a cloud rule is applied with the pre_webhook_id and post_webhook_id of kion_cloud_rule.",
+		Schema: map[string]*schema.Schema{
+			// Notice there is no 'id' field specified because it will be created.
+			"last_updated": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"callout_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The URL Kion sends the request to.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"owner_user_groups": {
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "Must provide at least the owner_user_groups field or the owner_users field.",
+				AtLeastOneOf: []string{"owner_user_groups", "owner_users"},
+			},
+			"owner_users": {
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "Must provide at least the owner_user_groups field or the owner_users field.",
+				AtLeastOneOf: []string{"owner_user_groups", "owner_users"},
+			},
+			"request_body": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The body of the request. It can use the template variables supported by Kion webhooks.",
+			},
+			"request_headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The headers to send with the request. Header values set in Kion that are not strings are read as their JSON encoding.",
+			},
+			"request_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "POST",
+				Description:  "The HTTP method of the request.",
+				ValidateFunc: validation.StringInSlice([]string{"GET", "POST", "PUT", "PATCH", "DELETE"}, false),
+			},
+			"should_send_secure_info": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, Kion includes secure information such as temporary credentials in the request.",
+			},
+			"skip_ssl": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, Kion does not validate the SSL certificate of the callout URL.",
+			},
+			"timeout_in_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "How long Kion waits for a response. Kion's default is used when not specified.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	})
+}
+
+// flattenWebhookHeaders encodes the request_headers of a webhook as the JSON
+// object Kion expects.
+func flattenWebhookHeaders(d *schema.ResourceData) (string, error) {
+	headers := d.Get("request_headers").(map[string]interface{})
+	if len(headers) == 0 {
+		return "", nil
+	}
+
+	b, err := json.Marshal(headers)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// inflateWebhookHeaders decodes the request headers Kion returns for a
+// webhook. Header values that are not strings, e.g. numbers set in the Kion
+// UI, are kept as their JSON encoding.
+func inflateWebhookHeaders(headers string) (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	if headers == "" {
+		return m, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(headers), &raw); err != nil {
+		return nil, fmt.Errorf("unable to decode request headers, expected a JSON object: %w", err)
+	}
+	for k, v := range raw {
+		var s string
+		if err := json.Unmarshal(v, &s); err != nil {
+			s = string(v)
+		}
+		m[k] = s
+	}
+	return m, nil
+}
+
+func resourceWebhookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+
+	headers, err := flattenWebhookHeaders(d)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create Webhook", err)...)
+		return diags
+	}
+
+	post := hc.WebhookCreate{
+		Name:                 d.Get("name").(string),
+		Description:          d.Get("description").(string),
+		CalloutURL:           d.Get("callout_url").(string),
+		RequestMethod:        d.Get("request_method").(string),
+		RequestHeaders:       headers,
+		RequestBody:          d.Get("request_body").(string),
+		UseRequestHeaders:    headers != "",
+		ShouldSendSecureInfo: d.Get("should_send_secure_info").(bool),
+		SkipSSL:              d.Get("skip_ssl").(bool),
+		TimeoutInSeconds:     d.Get("timeout_in_seconds").(int),
+		OwnerUserIds:         hc.FlattenGenericIDPointer(d, "owner_users"),
+		OwnerUserGroupIds:    hc.FlattenGenericIDPointer(d, "owner_user_groups"),
+	}
+
+	resp, err := client.POSTContext(ctx, "/v3/webhook", post)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to create Webhook", err)...)
+		return diags
+	} else if resp.RecordID == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create Webhook",
+			Detail:   "Kion returned an item ID of 0.",
+		})
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(resp.RecordID))
+
+	return resourceWebhookRead(ctx, d, m)
+}
+
+func resourceWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+	ID := d.Id()
+
+	resp := new(hc.WebhookResponse)
+	err := client.GETContext(ctx, fmt.Sprintf("/v3/webhook/%s", ID), resp)
+	if err != nil {
+		if hc.IsNotFound(err) {
+			tflog.Warn(ctx, "Webhook not found, removing from state", map[string]interface{}{"id": ID})
+			d.SetId("")
+			return diags
+		}
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Webhook", err)...)
+		return diags
+	}
+	item := resp.Data
+
+	headers, err := inflateWebhookHeaders(item.Webhook.RequestHeaders)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to read Webhook", err)...)
+		return diags
+	}
+
+	data := make(map[string]interface{})
+	data["callout_url"] = item.Webhook.CalloutURL
+	data["description"] = item.Webhook.Description
+	data["name"] = item.Webhook.Name
+	if hc.InflateObjectWithID(item.OwnerUserGroups) != nil {
+		data["owner_user_groups"] = hc.InflateObjectWithID(item.OwnerUserGroups)
+	}
+	if hc.InflateObjectWithID(item.OwnerUsers) != nil {
+		data["owner_users"] = hc.InflateObjectWithID(item.OwnerUsers)
+	}
+	data["request_body"] = item.Webhook.RequestBody
+	data["request_headers"] = headers
+	data["request_method"] = item.Webhook.RequestMethod
+	data["should_send_secure_info"] = item.Webhook.ShouldSendSecureInfo
+	data["skip_ssl"] = item.Webhook.SkipSSL
+	data["timeout_in_seconds"] = item.Webhook.TimeoutInSeconds
+
+	for k, v := range data {
+		if err := d.Set(k, v); err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to read and set Webhook", err)...)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceWebhookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+	ID := d.Id()
+
+	hasChanged := 0
+
+	// Determine if the attributes that are updatable are changed.
+	if d.HasChanges("callout_url",
+		"description",
+		"name",
+		"request_body",
+		"request_headers",
+		"request_method",
+		"should_send_secure_info",
+		"skip_ssl",
+		"timeout_in_seconds") {
+		hasChanged++
+
+		headers, err := flattenWebhookHeaders(d)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Webhook", err)...)
+			return diags
+		}
+
+		req := hc.WebhookUpdate{
+			Name:                 d.Get("name").(string),
+			Description:          d.Get("description").(string),
+			CalloutURL:           d.Get("callout_url").(string),
+			RequestMethod:        d.Get("request_method").(string),
+			RequestHeaders:       headers,
+			RequestBody:          d.Get("request_body").(string),
+			UseRequestHeaders:    headers != "",
+			ShouldSendSecureInfo: d.Get("should_send_secure_info").(bool),
+			SkipSSL:              d.Get("skip_ssl").(bool),
+			TimeoutInSeconds:     d.Get("timeout_in_seconds").(int),
+		}
+
+		err = client.PATCHContext(ctx, fmt.Sprintf("/v3/webhook/%s", ID), req)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics(d, "Unable to update Webhook", err)...)
+			return diags
+		}
+	}
+
+	// Determine if the owners have changed.
+	if d.HasChanges("owner_user_groups",
+		"owner_users") {
+		hasChanged++
+		arrAddOwnerUserGroupIds, arrRemoveOwnerUserGroupIds, _, _ := hc.AssociationChanged(d, "owner_user_groups")
+		arrAddOwnerUserIds, arrRemoveOwnerUserIds, _, _ := hc.AssociationChanged(d, "owner_users")
+
+		if len(arrAddOwnerUserGroupIds) > 0 ||
+			len(arrAddOwnerUserIds) > 0 {
+			_, err := client.POSTContext(ctx, fmt.Sprintf("/v3/webhook/%s/owner", ID), hc.ChangeOwners{
+				OwnerUserGroupIds: &arrAddOwnerUserGroupIds,
+				OwnerUserIds:      &arrAddOwnerUserIds,
+			})
+			if err != nil {
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to add owners on Webhook", err)...)
+				return diags
+			}
+		}
+
+		if len(arrRemoveOwnerUserGroupIds) > 0 ||
+			len(arrRemoveOwnerUserIds) > 0 {
+			err := client.DELETEContext(ctx, fmt.Sprintf("/v3/webhook/%s/owner", ID), hc.ChangeOwners{
+				OwnerUserGroupIds: &arrRemoveOwnerUserGroupIds,
+				OwnerUserIds:      &arrRemoveOwnerUserIds,
+			})
+			if err != nil {
+				diags = append(diags, apiErrorDiagnostics(d, "Unable to remove owners on Webhook", err)...)
+				return diags
+			}
+		}
+	}
+
+	if hasChanged > 0 {
+		if err := d.Set("last_updated", time.Now().Format(time.RFC850)); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Failed to set last_updated",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+
+	return resourceWebhookRead(ctx, d, m)
+}
+
+func resourceWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*hc.Client)
+	ID := d.Id()
+
+	err := client.DELETEContext(ctx, fmt.Sprintf("/v3/webhook/%s", ID), nil)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics(d, "Unable to delete Webhook", err)...)
+		return diags
+	}
+
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,66 @@
+package kion
+
+import (
+	"net/http"
+	"testing"
+
+	hc "github.com/kionsoftware/terraform-provider-kion/kion/internal/kionclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook(t *testing.T) {
+	var post hc.WebhookCreate
+	var body string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/webhook":
+			body = readTestBody(t, r, &post)
+			_, _ = w.Write([]byte(`{"status": 201, "record_id": 3}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/webhook/3":
+			_, _ = w.Write([]byte(`{"status": 200, "data": {
+				"webhook": {
+					"id": 3, "name": "notify", "callout_url": "https://hooks.example.com/kion", "request_method": "POST",
+					"request_headers": "{\"Content-Type\":\"application/json\"}", "request_body": "{}",
+					"use_request_headers": true, "skip_ssl": false, "timeout_in_seconds": 10
+				},
+				"owner_users": [{"id": 1}],
+				"owner_user_groups": []
+			}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	d := testCreate(t, resourceWebhook(), map[string]interface{}{
+		"name":            "notify",
+		"callout_url":     "https://hooks.example.com/kion",
+		"request_headers": map[string]interface{}{"Content-Type": "application/json"},
+		"request_body":    "{}",
+		"owner_users":     []interface{}{map[string]interface{}{"id": 1}},
+	}, client)
+	assert.Equal(t, "3", d.Id())
+	assert.Equal(t, "POST", post.RequestMethod)
+	assert.Equal(t, `{"Content-Type":"application/json"}`, post.RequestHeaders)
+	assert.True(t, post.UseRequestHeaders)
+	assert.Equal(t, []int{1}, *post.OwnerUserIds)
+
+	// Kion picks the timeout when it is not set.
+	assert.NotContains(t, body, "timeout_in_seconds")
+
+	// The headers are decoded and Kion's default timeout is read back.
+	assert.Equal(t, map[string]interface{}{"Content-Type": "application/json"}, d.Get("request_headers"))
+	assert.Equal(t, 10, d.Get("timeout_in_seconds"))
+}
+
+func TestInflateWebhookHeaders(t *testing.T) {
+	headers, err := inflateWebhookHeaders(`{"Content-Type": "application/json", "X-Retries": 3, "X-Debug": true}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"Content-Type": "application/json", "X-Retries": "3", "X-Debug": "true"}, headers)
+
+	headers, err = inflateWebhookHeaders("")
+	assert.NoError(t, err)
+	assert.Empty(t, headers)
+
+	_, err = inflateWebhookHeaders(`["Content-Type"]`)
+	assert.ErrorContains(t, err, "expected a JSON object")
+}
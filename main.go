@@ -1,18 +1,18 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/kionsoftware/terraform-provider-kion/kion"
 )
 
+// version is set by goreleaser at build time.
+var version = "dev"
+
 // Generate docs for website
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return kion.Provider()
-		},
+		ProviderFunc: kion.New(version),
 	})
 }